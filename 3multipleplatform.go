@@ -1,221 +1,33 @@
+//go:build !lambda_multiplatform
+
 package main
 
 import (
 	"context"
-	"encoding/csv"
 	"flag"
-	"fmt"
 	"log"
-	"math/rand"
-	"net/http"
-	"net/url"
 	"os"
-	"strings"
+	"os/signal"
 	"sync"
-	"time"
-
-	"github.com/gocolly/colly/v2"
-	"golang.org/x/time/rate"
+	"syscall"
 )
 
-// Platform defines the configuration for scraping a specific job platform
-type Platform struct {
-	Name      string
-	BaseURL   string
-	QueryPath string
-	Filters   map[string]string
-	Selector  PlatformSelector
-}
-
-// PlatformSelector defines CSS selectors for extracting job information
-type PlatformSelector struct {
-	JobContainer string
-	Title        string
-	Company      string
-	Location     string
-	Description  string
-	Salary       string
-	PostedDate   string
-}
-
-// Job represents a single job listing
-type Job struct {
-	Platform    string
-	Title       string
-	Company     string
-	Location    string
-	Description string
-	Salary      string
-	PostedDate  string
-	URL         string
-}
-
-// JobScraper manages the scraping process across multiple platforms
-type JobScraper struct {
-	jobs        []Job
-	jobsMutex   sync.Mutex
-	rateLimiter *rate.Limiter
-	platforms   []Platform
-	collector   *colly.Collector
-}
-
-// NewJobScraper creates a new JobScraper with configured rate limiting
-func NewJobScraper(platforms []Platform) *JobScraper {
-	rateLimiter := rate.NewLimiter(rate.Every(2*time.Second), 2)
-	collector := createCollector()
-	return &JobScraper{
-		jobs:        []Job{},
-		rateLimiter: rateLimiter,
-		platforms:   platforms,
-		collector:   collector,
-	}
-}
-
-// randomUserAgent returns a random user agent to mimic browser requests
-func randomUserAgent() string {
-	userAgents := []string{
-		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
-		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
-		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
-		"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:89.0) Gecko/20100101 Firefox/89.0",
-	}
-	return userAgents[rand.Intn(len(userAgents))]
-}
-
-// createCollector sets up a Colly collector with advanced configurations
-func createCollector() *colly.Collector {
-	c := colly.NewCollector(
-		colly.Async(true),
-		colly.MaxDepth(3),
-		colly.UserAgent(randomUserAgent()),
-		colly.AllowURLRevisit(),
-	)
-
-	// Set up proxy rotation (optional)
-	// c.SetProxy("http://proxy-ip:port")
-
-	c.WithTransport(&http.Transport{
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 10,
-		IdleConnTimeout:     30 * time.Second,
-	})
-
-	c.OnRequest(func(r *colly.Request) {
-		r.Headers.Set("Accept-Language", "en-US,en;q=0.5")
-		r.Headers.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8")
-	})
-
-	return c
-}
-
-// Scrape performs web scraping for a specific platform
-func (js *JobScraper) Scrape(platform Platform, jobTitle, location string, filters map[string]string) {
-	// Wait for rate limiter to avoid overwhelming the target website
-	err := js.rateLimiter.Wait(context.Background())
-	if err != nil {
-		log.Printf("Rate limit error: %v", err)
-		return
-	}
-
-	// Reset collector for each platform
-	js.collector.OnError(func(r *colly.Response, err error) {
-		log.Printf("Scrape Error on %s: %v", platform.Name, err)
-	})
-
-	// Parse job listings
-	js.collector.OnHTML(platform.Selector.JobContainer, func(e *colly.HTMLElement) {
-		job := Job{
-			Platform:    platform.Name,
-			Title:       sanitizeText(e.ChildText(platform.Selector.Title)),
-			Company:     sanitizeText(e.ChildText(platform.Selector.Company)),
-			Location:    sanitizeText(e.ChildText(platform.Selector.Location)),
-			Description: sanitizeText(e.ChildText(platform.Selector.Description)),
-			Salary:      sanitizeText(e.ChildText(platform.Selector.Salary)),
-			PostedDate:  sanitizeText(e.ChildText(platform.Selector.PostedDate)),
-			URL:         e.Request.URL.String(),
-		}
-
-		// Only add job if it has essential information
-		if job.Title != "" && job.Company != "" {
-			js.jobsMutex.Lock()
-			js.jobs = append(js.jobs, job)
-			js.jobsMutex.Unlock()
-		}
-	})
-
-	// Construct search URL
-	baseURL := fmt.Sprintf("%s%s?q=%s&l=%s",
-		platform.BaseURL,
-		platform.QueryPath,
-		url.QueryEscape(jobTitle),
-		url.QueryEscape(location),
-	)
-
-	// Add additional filters
-	for key, value := range filters {
-		baseURL += fmt.Sprintf("&%s=%s", key, url.QueryEscape(value))
-	}
-
-	// Visit the constructed URL
-	err = js.collector.Visit(baseURL)
-	if err != nil {
-		log.Printf("Failed to visit URL for %s: %v", platform.Name, err)
-	}
-
-	// Wait for all requests to complete
-	js.collector.Wait()
-}
-
-// sanitizeText removes unnecessary whitespace
-func sanitizeText(text string) string {
-	return strings.TrimSpace(text)
-}
-
-// SaveToCSV exports job listings to a CSV file
-func (js *JobScraper) SaveToCSV(filename string) {
-	file, err := os.Create(filename)
-	if err != nil {
-		log.Fatalf("Failed to create file: %v", err)
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// Write CSV headers
-	headers := []string{"Platform", "Title", "Company", "Location", "Description", "Salary", "PostedDate", "URL"}
-	if err := writer.Write(headers); err != nil {
-		log.Fatalf("Error writing headers: %v", err)
-	}
-
-	// Write job data
-	js.jobsMutex.Lock()
-	defer js.jobsMutex.Unlock()
-
-	for _, job := range js.jobs {
-		record := []string{
-			job.Platform,
-			job.Title,
-			job.Company,
-			job.Location,
-			job.Description,
-			job.Salary,
-			job.PostedDate,
-			job.URL,
-		}
-		if err := writer.Write(record); err != nil {
-			log.Printf("Error writing job record: %v", err)
-		}
-	}
-
-	log.Printf("Saved %d jobs to %s", len(js.jobs), filename)
-}
-
 func main() {
 	// Command-line flags
 	jobTitle := flag.String("title", "software engineer", "Job title to search for")
 	location := flag.String("location", "remote", "Job location to search for")
 	outputFile := flag.String("output", "multiplatformjobs.csv", "Output file for job results")
+	cacheDir := flag.String("cache-dir", "./cache", "directory for the persistent on-disk visit queue and dedup store (empty disables persistence)")
+	resume := flag.Bool("resume", false, "resume an interrupted crawl by replaying each platform's persisted queue")
+	maxQueueMemory := flag.Int("max-queue-memory", 100000, "max seen-URL hashes a persistent queue keeps in memory (0 = unbounded)")
+	scraperRulesPath := flag.String("scraper-rules", "", "path to a JSON file of scraper rules (regex/query/xpath) run against every response")
+	scrapersSelect := flag.String("scrapers", "all", `"all" or a comma-separated list of scraper rule names to activate`)
+	skillsConfigPath := flag.String("skills-config", "", "JSON file with skill keywords for post-scrape tagging (defaults to a built-in taxonomy)")
+	outputJSON := flag.String("output-json", "", "also save job results to this JSON file (default: JSON output disabled)")
+	proxyFile := flag.String("proxy-file", "", "file of HTTP/SOCKS5 proxy URLs, one per line (falls back to the PROXY_LIST env var)")
+	maxProxyRetries := flag.Int("max-proxy-retries", 2, "how many times to retry a request on a different proxy before giving up")
+	useTor := flag.Bool("tor", false, "shortcut for routing through a local Tor SOCKS5 proxy (socks5://127.0.0.1:9050)")
+	dashboardAddr := flag.String("dashboard", "", "if set (e.g. :8080), run as a long-running service with a live monitoring/reconfiguration dashboard on this address instead of a one-shot crawl")
 	flag.Parse()
 
 	// Define multiple job platforms
@@ -264,19 +76,84 @@ func main() {
 		},
 	}
 
+	// Proxy pool (optional): load from --proxy-file/PROXY_LIST, with --tor as
+	// a shortcut for a local Tor SOCKS5 proxy.
+	proxies := loadProxyList(*proxyFile)
+	if *useTor {
+		proxies = append([]string{"socks5://127.0.0.1:9050"}, proxies...)
+	}
+	var proxyPool *ProxyPool
+	if len(proxies) > 0 {
+		proxyPool = NewProxyPool(proxies)
+	}
+
 	// Create and run scraper
-	scraper := NewJobScraper(platforms)
+	scraper := NewJobScraper(platforms, proxyPool, *maxProxyRetries)
+	scraper.CacheDir = *cacheDir
+	scraper.Resume = *resume
+	scraper.MaxQueueMemory = *maxQueueMemory
+
+	if *scraperRulesPath != "" {
+		set, err := LoadScraperRules(*scraperRulesPath)
+		if err != nil {
+			log.Fatalf("Failed to load scraper rules: %v", err)
+		}
+		scraper.scraperRules = set.activeRules(parseScraperSelection(*scrapersSelect))
+	}
+
+	if *skillsConfigPath != "" {
+		cfg, err := LoadSkillConfig(*skillsConfigPath)
+		if err != nil {
+			log.Fatalf("Failed to load skill config: %v", err)
+		}
+		scraper.SkillConfig = cfg
+	}
+
+	if *dashboardAddr != "" {
+		// Long-running service: each platform gets a cancellable supervisor
+		// goroutine, and Ctrl+C triggers a final dump before shutting down.
+		ctx, cancel := context.WithCancel(context.Background())
+		signalChan := make(chan os.Signal, 1)
+		signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-signalChan
+			cancel()
+		}()
+
+		if err := scraper.Serve(ctx, *dashboardAddr, *jobTitle, *location); err != nil {
+			log.Fatalf("Dashboard server failed: %v", err)
+		}
+
+		scraper.PostProcess()
+		scraper.SaveToCSV(*outputFile)
+		if *outputJSON != "" {
+			if err := scraper.SaveToJSON(*outputJSON); err != nil {
+				log.Printf("Failed to save JSON: %v", err)
+			}
+		}
+		return
+	}
 
 	var wg sync.WaitGroup
 	for _, platform := range platforms {
 		wg.Add(1)
 		go func(p Platform) {
 			defer wg.Done()
-			scraper.Scrape(p, *jobTitle, *location, p.Filters)
+			scraper.Scrape(context.Background(), p, *jobTitle, *location, p.Filters)
 		}(platform)
 	}
 	wg.Wait()
 
+	// Tag skills, normalize posted dates, and detect work mode now that
+	// every platform's jobs have been collected.
+	scraper.PostProcess()
+
 	// Save results to CSV
 	scraper.SaveToCSV(*outputFile)
-}
\ No newline at end of file
+
+	if *outputJSON != "" {
+		if err := scraper.SaveToJSON(*outputJSON); err != nil {
+			log.Printf("Failed to save JSON: %v", err)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+//go:build !lambda_multiplatform
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// PlatformStatus is a single platform's live state, as reported by the
+// dashboard.
+type PlatformStatus struct {
+	Name            string  `json:"name"`
+	JobsScraped     int64   `json:"jobs_scraped"`
+	QueueDepth      int     `json:"queue_depth"`
+	Paused          bool    `json:"paused"`
+	RateLimitPerSec float64 `json:"rate_limit_per_sec"`
+}
+
+// DashboardStatus is the full payload served by /api/status and streamed
+// over /api/stream.
+type DashboardStatus struct {
+	Platforms []PlatformStatus    `json:"platforms"`
+	Errors    []dashboardLogEntry `json:"errors"`
+}
+
+// Snapshot reports every platform's current jobs-scraped count, queue
+// depth, pause state, and rate limit, plus the recent error log tail.
+func (js *JobScraper) Snapshot() DashboardStatus {
+	status := DashboardStatus{}
+
+	for _, platform := range js.platforms {
+		ctrl := js.controlFor(platform.Name)
+
+		depth := 0
+		if queue, ok := js.existingQueue(platform.Name); ok {
+			depth = queue.Depth()
+		}
+
+		status.Platforms = append(status.Platforms, PlatformStatus{
+			Name:            platform.Name,
+			JobsScraped:     atomic.LoadInt64(js.scrapedCounter(platform.Name)),
+			QueueDepth:      depth,
+			Paused:          ctrl.isPaused(),
+			RateLimitPerSec: float64(ctrl.limiter.Limit()),
+		})
+	}
+
+	js.errorLogMutex.Lock()
+	status.Errors = append([]dashboardLogEntry(nil), js.errorLog...)
+	js.errorLogMutex.Unlock()
+
+	return status
+}
+
+// Serve turns js into a long-running service: every platform gets its own
+// cancellable supervisor goroutine (runPlatform), in place of the one-shot
+// fan-out a plain CLI run uses, and the dashboard is served on addr until
+// ctx is cancelled.
+func (js *JobScraper) Serve(ctx context.Context, addr, jobTitle, location string) error {
+	for _, platform := range js.platforms {
+		js.setQuery(platform.Name, jobTitle, location)
+		go js.runPlatform(ctx, platform)
+	}
+
+	server := &http.Server{Addr: addr, Handler: js.dashboardMux()}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Dashboard listening on %s", addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// runPlatform supervises platform's Scrape passes: it runs one to
+// completion (or cancellation), then, if its control was asked to requery,
+// relaunches with whatever query is now current. It returns once ctx is
+// cancelled or a pass finishes with no requery pending.
+func (js *JobScraper) runPlatform(ctx context.Context, platform Platform) {
+	ctrl := js.controlFor(platform.Name)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		passCtx, cancel := context.WithCancel(ctx)
+		ctrl.setCancel(cancel)
+
+		title, location := js.currentQuery(platform.Name)
+		js.Scrape(passCtx, platform, title, location, platform.Filters)
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ctrl.restart:
+			continue
+		default:
+			return
+		}
+	}
+}
+
+// dashboardMux wires up the monitoring page, its live-status feed, and the
+// runtime-reconfiguration endpoints.
+func (js *JobScraper) dashboardMux() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, dashboardHTML)
+	})
+
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(js.Snapshot())
+	})
+
+	mux.HandleFunc("/api/stream", js.handleStream)
+	mux.HandleFunc("/api/pause", func(w http.ResponseWriter, r *http.Request) { js.handlePause(w, r, true) })
+	mux.HandleFunc("/api/resume", func(w http.ResponseWriter, r *http.Request) { js.handlePause(w, r, false) })
+	mux.HandleFunc("/api/rate", js.handleRate)
+	mux.HandleFunc("/api/query", js.handleQuery)
+	mux.HandleFunc("/api/dump", js.handleDump)
+
+	return mux
+}
+
+// handleStream streams a Snapshot over server-sent events every 2 seconds
+// for the dashboard page's live counters.
+func (js *JobScraper) handleStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if data, err := json.Marshal(js.Snapshot()); err == nil {
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (js *JobScraper) handlePause(w http.ResponseWriter, r *http.Request, paused bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST required", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.URL.Query().Get("platform")
+	if name == "" {
+		http.Error(w, "platform is required", http.StatusBadRequest)
+		return
+	}
+
+	js.controlFor(name).setPaused(paused)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRate adjusts a platform's rate.Limiter interval at runtime, e.g.
+// POST /api/rate?platform=Indeed&interval_ms=500.
+func (js *JobScraper) handleRate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST required", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.URL.Query().Get("platform")
+	intervalMs, err := strconv.Atoi(r.URL.Query().Get("interval_ms"))
+	if name == "" || err != nil || intervalMs <= 0 {
+		http.Error(w, "platform and a positive interval_ms are required", http.StatusBadRequest)
+		return
+	}
+
+	js.controlFor(name).limiter.SetLimit(rate.Every(time.Duration(intervalMs) * time.Millisecond))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleQuery changes a platform's active jobTitle/location and requeries
+// it immediately instead of waiting for its current queue to drain, e.g.
+// POST /api/query?platform=Indeed&title=staff+engineer&location=remote.
+func (js *JobScraper) handleQuery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST required", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.URL.Query().Get("platform")
+	title := r.URL.Query().Get("title")
+	location := r.URL.Query().Get("location")
+	if name == "" || (title == "" && location == "") {
+		http.Error(w, "platform and at least one of title/location are required", http.StatusBadRequest)
+		return
+	}
+
+	currentTitle, currentLocation := js.currentQuery(name)
+	if title == "" {
+		title = currentTitle
+	}
+	if location == "" {
+		location = currentLocation
+	}
+
+	js.setQuery(name, title, location)
+	js.controlFor(name).requery()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleDump runs PostProcess and writes out the jobs collected so far,
+// without stopping the crawl, e.g. POST /api/dump?csv=out.csv&json=out.json.
+func (js *JobScraper) handleDump(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST required", http.StatusMethodNotAllowed)
+		return
+	}
+
+	js.PostProcess()
+
+	csvPath := r.URL.Query().Get("csv")
+	if csvPath == "" {
+		csvPath = "multiplatformjobs.csv"
+	}
+	js.SaveToCSV(csvPath)
+
+	if jsonPath := r.URL.Query().Get("json"); jsonPath != "" {
+		if err := js.SaveToJSON(jsonPath); err != nil {
+			http.Error(w, fmt.Sprintf("json dump failed: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dashboardHTML is the embedded monitoring page: a live-updating table of
+// per-platform counters fed by /api/stream, an error log tail, and simple
+// forms against the reconfiguration endpoints above.
+const dashboardHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>getalljobs dashboard</title>
+<style>
+  body { font-family: sans-serif; margin: 2rem; }
+  table { border-collapse: collapse; width: 100%; margin-bottom: 1.5rem; }
+  th, td { border: 1px solid #ccc; padding: 0.4rem 0.6rem; text-align: left; }
+  #errors { max-height: 200px; overflow-y: auto; background: #111; color: #eee; padding: 0.5rem; font-family: monospace; font-size: 0.85rem; }
+  form { display: inline-block; margin-right: 1rem; }
+</style>
+</head>
+<body>
+<h1>getalljobs dashboard</h1>
+
+<table id="platforms">
+  <thead>
+    <tr><th>Platform</th><th>Jobs scraped</th><th>Queue depth</th><th>Paused</th><th>Rate (req/s)</th></tr>
+  </thead>
+  <tbody></tbody>
+</table>
+
+<h2>Controls</h2>
+<form onsubmit="return postForm(this, '/api/pause')">
+  <input name="platform" placeholder="platform"><button>Pause</button>
+</form>
+<form onsubmit="return postForm(this, '/api/resume')">
+  <input name="platform" placeholder="platform"><button>Resume</button>
+</form>
+<form onsubmit="return postForm(this, '/api/rate')">
+  <input name="platform" placeholder="platform">
+  <input name="interval_ms" placeholder="interval ms"><button>Set rate</button>
+</form>
+<form onsubmit="return postForm(this, '/api/query')">
+  <input name="platform" placeholder="platform">
+  <input name="title" placeholder="title">
+  <input name="location" placeholder="location"><button>Requery</button>
+</form>
+<form onsubmit="return postForm(this, '/api/dump')">
+  <input name="csv" placeholder="csv path">
+  <input name="json" placeholder="json path"><button>Dump now</button>
+</form>
+
+<h2>Recent errors</h2>
+<div id="errors"></div>
+
+<script>
+function postForm(form, path) {
+  var params = new URLSearchParams(new FormData(form));
+  fetch(path + '?' + params.toString(), { method: 'POST' });
+  return false;
+}
+
+function render(status) {
+  var rows = status.platforms.map(function(p) {
+    return '<tr><td>' + p.name + '</td><td>' + p.jobs_scraped + '</td><td>' +
+      p.queue_depth + '</td><td>' + p.paused + '</td><td>' +
+      p.rate_limit_per_sec.toFixed(2) + '</td></tr>';
+  }).join('');
+  document.querySelector('#platforms tbody').innerHTML = rows;
+
+  var errors = (status.errors || []).slice(-50).reverse().map(function(e) {
+    return e.time + ' [' + e.platform + '] ' + e.message;
+  }).join('\n');
+  document.querySelector('#errors').textContent = errors;
+}
+
+var source = new EventSource('/api/stream');
+source.onmessage = function(event) { render(JSON.parse(event.data)); };
+</script>
+</body>
+</html>
+`
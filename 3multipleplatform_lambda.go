@@ -0,0 +1,183 @@
+//go:build lambda_multiplatform
+
+package main
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// multiplatformLambdaRequest is the event payload accepted by the Lambda
+// handler, e.g. from an API Gateway proxy integration's JSON body.
+type multiplatformLambdaRequest struct {
+	JobTitle  string            `json:"jobTitle"`
+	Location  string            `json:"location"`
+	Filters   map[string]string `json:"filters"`
+	Platforms []string          `json:"platforms"`
+}
+
+// multiplatformLambdaCacheEntry is a single warm-container cache hit.
+type multiplatformLambdaCacheEntry struct {
+	jobs      []Job
+	scrapedAt time.Time
+}
+
+// multiplatformLambdaCacheTTL bounds how long a warm container reuses a
+// previous scrape for the same request instead of re-crawling every platform.
+const multiplatformLambdaCacheTTL = 5 * time.Minute
+
+// multiplatformLambdaMaxJobs caps jobs per platform so a single invocation
+// can't run long enough to hit Lambda's execution time limit.
+const multiplatformLambdaMaxJobs = 20
+
+var (
+	multiplatformLambdaCacheMu sync.Mutex
+	multiplatformLambdaCache   = map[[16]byte]multiplatformLambdaCacheEntry{}
+)
+
+// multiplatformLambdaCacheKey hashes the full request so two requests only
+// share a cache entry when every field (including filters/platforms) matches.
+func multiplatformLambdaCacheKey(req multiplatformLambdaRequest) [16]byte {
+	data, _ := json.Marshal(req)
+	return md5.Sum(data)
+}
+
+// selectPlatforms returns the subset of allPlatforms named in want (all of
+// them if want is empty).
+func selectPlatforms(allPlatforms []Platform, want []string) []Platform {
+	if len(want) == 0 {
+		return allPlatforms
+	}
+
+	wanted := make(map[string]bool, len(want))
+	for _, name := range want {
+		wanted[name] = true
+	}
+
+	var selected []Platform
+	for _, platform := range allPlatforms {
+		if wanted[platform.Name] {
+			selected = append(selected, platform)
+		}
+	}
+	return selected
+}
+
+// defaultPlatforms mirrors the CLI build's platform list, with MaxJobs
+// capped so a single Lambda invocation can't run long enough to hit the
+// execution time limit.
+func defaultPlatforms() []Platform {
+	return []Platform{
+		{
+			Name:      "Indeed",
+			BaseURL:   "https://www.indeed.com",
+			QueryPath: "/jobs",
+			MaxJobs:   multiplatformLambdaMaxJobs,
+			Selector: PlatformSelector{
+				JobContainer: ".job_seen_beacon",
+				Title:        ".jobTitle",
+				Company:      ".companyName",
+				Location:     ".companyLocation",
+				Description:  ".job-snippet",
+				Salary:       ".salary-snippet",
+				PostedDate:   ".date",
+			},
+		},
+		{
+			Name:      "LinkedIn",
+			BaseURL:   "https://www.linkedin.com",
+			QueryPath: "/jobs/search",
+			MaxJobs:   multiplatformLambdaMaxJobs,
+			Selector: PlatformSelector{
+				JobContainer: ".base-card",
+				Title:        ".base-search-card__title",
+				Company:      ".base-search-card__subtitle",
+				Location:     ".job-search-card__location",
+				Description:  ".job-description",
+				Salary:       ".salary-info",
+				PostedDate:   ".listed-time",
+			},
+		},
+		{
+			Name:      "Glassdoor",
+			BaseURL:   "https://www.glassdoor.com",
+			QueryPath: "/Job/jobs.htm",
+			MaxJobs:   multiplatformLambdaMaxJobs,
+			Selector: PlatformSelector{
+				JobContainer: ".react-job-listing",
+				Title:        ".job-title",
+				Company:      ".job-employer",
+				Location:     ".job-location",
+				Description:  ".job-description",
+				Salary:       ".salary-info",
+				PostedDate:   ".job-posted",
+			},
+		},
+	}
+}
+
+// mergeFilters layers request-supplied filters on top of a platform's own,
+// letting callers override per-platform defaults.
+func mergeFilters(platformFilters, requestFilters map[string]string) map[string]string {
+	merged := make(map[string]string, len(platformFilters)+len(requestFilters))
+	for k, v := range platformFilters {
+		merged[k] = v
+	}
+	for k, v := range requestFilters {
+		merged[k] = v
+	}
+	return merged
+}
+
+// handleMultiplatformLambdaRequest scrapes every requested platform and
+// returns the merged jobs, serving a warm-container cache hit when one is
+// fresh enough instead of re-scraping.
+func handleMultiplatformLambdaRequest(ctx context.Context, req multiplatformLambdaRequest) ([]Job, error) {
+	key := multiplatformLambdaCacheKey(req)
+
+	multiplatformLambdaCacheMu.Lock()
+	if entry, ok := multiplatformLambdaCache[key]; ok && time.Since(entry.scrapedAt) < multiplatformLambdaCacheTTL {
+		multiplatformLambdaCacheMu.Unlock()
+		return entry.jobs, nil
+	}
+	multiplatformLambdaCacheMu.Unlock()
+
+	platforms := selectPlatforms(defaultPlatforms(), req.Platforms)
+	if len(platforms) == 0 {
+		return nil, fmt.Errorf("no matching platforms for %v", req.Platforms)
+	}
+
+	scraper := NewJobScraper(platforms, nil, 0)
+
+	var wg sync.WaitGroup
+	for _, platform := range platforms {
+		wg.Add(1)
+		go func(p Platform) {
+			defer wg.Done()
+			scraper.Scrape(ctx, p, req.JobTitle, req.Location, mergeFilters(p.Filters, req.Filters))
+		}(platform)
+	}
+	wg.Wait()
+
+	scraper.PostProcess()
+
+	multiplatformLambdaCacheMu.Lock()
+	multiplatformLambdaCache[key] = multiplatformLambdaCacheEntry{jobs: scraper.jobs, scrapedAt: time.Now()}
+	multiplatformLambdaCacheMu.Unlock()
+
+	return scraper.jobs, nil
+}
+
+// main is the Lambda entrypoint. Build with build_multiplatform.sh, or
+// directly via:
+//
+//	GOOS=linux GOARCH=arm64 go build -tags lambda.norpc,lambda_multiplatform -o bootstrap .
+func main() {
+	lambda.Start(handleMultiplatformLambdaRequest)
+}
@@ -1,11 +1,8 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"encoding/csv"
 	"encoding/json"
-	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -27,6 +24,19 @@ type ConfigData struct {
 	WhatsappNumber string   `json:"whatsapp_number"`
 	RedisURL       string   `json:"redis_url"`
 	ProxyList      []string `json:"proxy_list"`
+
+	// DashboardAddr is used by the "scrape"/"serve" subcommands when their
+	// -dashboard flag is left unset.
+	DashboardAddr string `json:"dashboard_addr,omitempty"`
+
+	// Notifiers lists every notification sink to construct; with it empty,
+	// buildNotifiers falls back to a single WhatsApp notifier if
+	// WhatsappAPIKey/WhatsappNumber are set.
+	Notifiers []NotifierConfig `json:"notifiers"`
+
+	// Sinks lists every output sink to construct; with it empty, runScrape
+	// falls back to a single CSV sink at the -output path.
+	Sinks []SinkConfig `json:"sinks"`
 }
 
 // Platform defines the configuration for scraping a specific job platform
@@ -67,17 +77,30 @@ type Job struct {
 
 // JobScraper manages the scraping process across multiple platforms
 type JobScraper struct {
-	jobs         []Job
-	jobsMutex    sync.Mutex
-	rateLimiter  *rate.Limiter
-	platforms    []Platform
-	collector    *colly.Collector
-	redis        *redis.Client
-	config       ConfigData
-	seenJobs     map[string]bool
-	seenJobMutex sync.Mutex
+	// recentJobs bounds how many jobs the dashboard can list without the
+	// unbounded memory growth js.jobs used to have; durable persistence is
+	// the sinks' job now.
+	recentJobs      []Job
+	recentJobsMutex sync.Mutex
+	rateLimiter     *rate.Limiter
+	platforms       []Platform
+	collector       *colly.Collector
+	redis           *redis.Client
+	config          ConfigData
+	seenJobs        map[string]bool
+	seenJobMutex    sync.Mutex
+	notifiers       []Notifier
+	sinks           []Sink
+	events          *jobEventBus
+	stats           *scraperStats
+	scheduler       *Scheduler
+	deadline        *deadlineTimer
 }
 
+// maxRecentJobs caps js.recentJobs so a long-running daemon's dashboard
+// memory stays bounded.
+const maxRecentJobs = 500
+
 // Common user agents for rotation
 var userAgents = []string{
 	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
@@ -127,13 +150,17 @@ func NewJobScraper(platforms []Platform, config ConfigData) *JobScraper {
 	})
 
 	return &JobScraper{
-		jobs:        []Job{},
 		rateLimiter: rate.NewLimiter(rate.Every(2*time.Second), 2),
 		platforms:   platforms,
 		collector:   createCollector(),
 		redis:       rdb,
 		config:      config,
 		seenJobs:    make(map[string]bool),
+		notifiers:   buildNotifiers(config),
+		sinks:       buildSinks(config),
+		events:      newJobEventBus(),
+		stats:       newScraperStats(),
+		deadline:    newDeadlineTimer(),
 	}
 }
 
@@ -154,113 +181,75 @@ func createCollector() *colly.Collector {
 }
 
 // JobScraper Methods
-func (js *JobScraper) sendWhatsAppMessage(job Job) error {
-	message := fmt.Sprintf("üÜï New Job Alert!\n\n"+
-		"üè¢ Company: %s\n"+
-		"üíº Position: %s\n"+
-		"üìç Location: %s\n"+
-		"üí∞ Salary: %s\n\n"+
-		"üîó Apply here: %s",
-		job.Company, job.Title, job.Location, job.Salary, job.URL)
-
-	payload := map[string]interface{}{
-		"phone":   js.config.WhatsappNumber,
-		"message": message,
-	}
-
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return err
-	}
-
-	req, err := http.NewRequest("POST", "https://api.whatsapp.com/v1/messages", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
-	}
-
-	req.Header.Set("Authorization", "Bearer "+js.config.WhatsappAPIKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("whatsapp API returned status: %d", resp.StatusCode)
-	}
-
-	return nil
-}
-
-func (js *JobScraper) isNewJob(job Job) bool {
+func (js *JobScraper) isNewJob(ctx context.Context, job Job) bool {
 	js.seenJobMutex.Lock()
 	defer js.seenJobMutex.Unlock()
 
-	jobID := fmt.Sprintf("%s-%s-%s", job.Platform, job.Company, job.Title)
+	jobID := job.ID
 	if js.seenJobs[jobID] {
+		js.stats.recordDedup(false)
+		return false
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-js.deadline.readCancel():
 		return false
+	default:
 	}
 
-	exists, err := js.redis.Exists(context.Background(), jobID).Result()
+	exists, err := js.redis.Exists(ctx, jobID).Result()
 	if err != nil {
 		log.Printf("Redis error: %v", err)
+		js.stats.recordDedup(true)
 		return true
 	}
 
 	if exists == 1 {
+		js.stats.recordDedup(false)
 		return false
 	}
 
 	js.seenJobs[jobID] = true
-	js.redis.Set(context.Background(), jobID, time.Now().String(), 7*24*time.Hour)
+	js.redis.Set(ctx, jobID, time.Now().String(), 7*24*time.Hour)
+	js.stats.recordDedup(true)
 	return true
 }
 
-func (js *JobScraper) SaveToCSV(filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+// rememberRecentJob keeps the dashboard's /api/jobs list populated without
+// the unbounded growth js.jobs used to have; durable storage is the sinks'
+// job now.
+func (js *JobScraper) rememberRecentJob(job Job) {
+	js.recentJobsMutex.Lock()
+	defer js.recentJobsMutex.Unlock()
 
-	header := []string{"Platform", "Title", "Company", "Location", "Salary", 
-		"Posted Date", "URL", "Skills", "Added At"}
-	if err := writer.Write(header); err != nil {
-		return err
+	js.recentJobs = append(js.recentJobs, job)
+	if overflow := len(js.recentJobs) - maxRecentJobs; overflow > 0 {
+		js.recentJobs = js.recentJobs[overflow:]
 	}
-
-	for _, job := range js.jobs {
-		record := []string{
-			job.Platform,
-			job.Title,
-			job.Company,
-			job.Location,
-			job.Salary,
-			job.PostedDate,
-			job.URL,
-			strings.Join(job.Skills, "|"),
-			job.AddedAt.Format(time.RFC3339),
-		}
-		if err := writer.Write(record); err != nil {
-			return err
-		}
-	}
-
-	return nil
 }
 
-func (js *JobScraper) Scrape(platform Platform, jobTitle, location string) {
-	err := js.rateLimiter.Wait(context.Background())
+// Scrape runs platform's search and blocks until it completes, ctx is
+// cancelled, or the scraper's deadline (see SetScrapeDeadline) passes -
+// whichever comes first, so a stalled platform can't run forever.
+func (js *JobScraper) Scrape(ctx context.Context, platform Platform, jobTitle, location string) {
+	err := js.rateLimiter.Wait(ctx)
 	if err != nil {
 		log.Printf("Rate limit error: %v", err)
 		return
 	}
+	js.stats.recordRateLimitWait()
+
+	js.collector.OnRequest(func(r *colly.Request) {
+		select {
+		case <-ctx.Done():
+			r.Abort()
+		case <-js.deadline.writeCancel():
+			r.Abort()
+		default:
+		}
+	})
 
 	js.collector.OnHTML(platform.Selector.JobContainer, func(e *colly.HTMLElement) {
 		job := Job{
@@ -275,15 +264,20 @@ func (js *JobScraper) Scrape(platform Platform, jobTitle, location string) {
 			Skills:      extractSkills(e.ChildText(platform.Selector.Skills)),
 			AddedAt:     time.Now(),
 		}
+		job.ID = fmt.Sprintf("%s-%s-%s", job.Platform, job.Company, job.Title)
 
-		if job.Title != "" && job.Company != "" && js.isNewJob(job) {
-			js.jobsMutex.Lock()
-			js.jobs = append(js.jobs, job)
-			js.jobsMutex.Unlock()
+		if job.Title != "" && job.Company != "" && js.isNewJob(ctx, job) {
+			js.rememberRecentJob(job)
 
-			if err := js.sendWhatsAppMessage(job); err != nil {
-				log.Printf("Failed to send WhatsApp notification: %v", err)
+			for _, sink := range js.sinks {
+				if err := sink.Write(ctx, job); err != nil {
+					log.Printf("Sink write failed: %v", err)
+				}
 			}
+
+			js.stats.recordScraped(platform.Name)
+			js.events.publish(job)
+			go js.notify(context.Background(), job)
 		}
 	})
 
@@ -297,7 +291,17 @@ func (js *JobScraper) Scrape(platform Platform, jobTitle, location string) {
 		log.Printf("Failed to visit %s: %v", platform.Name, err)
 	}
 
-	js.collector.Wait()
+	waitDone := make(chan struct{})
+	go func() {
+		js.collector.Wait()
+		close(waitDone)
+	}()
+
+	select {
+	case <-waitDone:
+	case <-ctx.Done():
+	case <-js.deadline.writeCancel():
+	}
 }
 
 // Configuration functions
@@ -347,35 +351,23 @@ func initializePlatforms() []Platform {
 	}
 }
 
-func main() {
-	jobTitle := flag.String("title", "", "Job title to search for")
-	location := flag.String("location", "", "Job location")
-	configFile := flag.String("config", "config.json", "Path to configuration file")
-	outputFile := flag.String("output", "jobs.csv", "Output CSV file")
-	flag.Parse()
-
-	configData, err := loadConfig(*configFile)
+// loadPlatforms reads a []Platform from path, the JSON file `scraper init`
+// writes, letting users add boards without recompiling. initializePlatforms
+// is only used as a fallback when this fails.
+func loadPlatforms(path string) ([]Platform, error) {
+	file, err := os.ReadFile(path)
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		return nil, err
 	}
 
-	platforms := initializePlatforms()
-	scraper := NewJobScraper(platforms, configData)
-	
-	var wg sync.WaitGroup
-	for _, platform := range platforms {
-		wg.Add(1)
-		go func(p Platform) {
-			defer wg.Done()
-			scraper.Scrape(p, *jobTitle, *location)
-		}(platform)
+	var platforms []Platform
+	if err := json.Unmarshal(file, &platforms); err != nil {
+		return nil, err
 	}
-	wg.Wait()
-
-	if err := scraper.SaveToCSV(*outputFile); err != nil {
-		log.Fatalf("Failed to save results: %v", err)
+	if len(platforms) == 0 {
+		return nil, fmt.Errorf("%s contains no platforms", path)
 	}
+	return platforms, nil
 }
 
-
-// go run main.go -title "Software Engineer" -location "Remote" -config config.json
\ No newline at end of file
+// go run main.go scrape -title "Software Engineer" -location "Remote" -config config.json
\ No newline at end of file
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// main dispatches to a subcommand: "init" scaffolds config.json and
+// platforms.json, "scrape" runs a bounded crawl, and "serve" is the same as
+// "scrape" but with the dashboard enabled by default. With no recognized
+// subcommand, every argument is treated as a "scrape" flag so existing
+// invocations built before the subcommands existed keep working.
+func main() {
+	if len(os.Args) < 2 {
+		runScrape(nil, "")
+		return
+	}
+
+	switch os.Args[1] {
+	case "init":
+		runInit(os.Args[2:])
+	case "serve":
+		runScrape(os.Args[2:], ":8080")
+	case "scrape":
+		runScrape(os.Args[2:], "")
+	default:
+		runScrape(os.Args[1:], "")
+	}
+}
+
+// scrapeFlags are shared by the "scrape" and "serve" subcommands.
+type scrapeFlags struct {
+	jobTitle      *string
+	location      *string
+	configFile    *string
+	platformsFile *string
+	outputFile    *string
+	interval      *time.Duration
+	workers       *int
+	dashboardAddr *string
+}
+
+func registerScrapeFlags(fs *flag.FlagSet, defaultDashboard string) *scrapeFlags {
+	return &scrapeFlags{
+		jobTitle:      fs.String("title", "", "Job title to search for"),
+		location:      fs.String("location", "", "Job location"),
+		configFile:    fs.String("config", "config.json", "Path to configuration file"),
+		platformsFile: fs.String("platforms", "platforms.json", "Path to platforms file (falls back to the built-in list if missing)"),
+		outputFile:    fs.String("output", "jobs.csv", "Output CSV file"),
+		interval:      fs.Duration("interval", 30*time.Minute, "How often to re-run each platform's search"),
+		workers:       fs.Int("workers", 2, "Number of concurrent scrape workers"),
+		dashboardAddr: fs.String("dashboard", defaultDashboard, "Address to serve the live monitoring dashboard on, e.g. :8080 (if empty, the config's dashboard_addr is used; disabled if both are empty)"),
+	}
+}
+
+// runScrape implements the "scrape" and "serve" subcommands: it loads
+// config/platforms, enqueues a recurring ScrapeJob per platform, and runs
+// the worker pool until interrupted.
+func runScrape(args []string, defaultDashboard string) {
+	fs := flag.NewFlagSet("scrape", flag.ExitOnError)
+	flags := registerScrapeFlags(fs, defaultDashboard)
+	fs.Parse(args)
+
+	configData, err := loadConfig(*flags.configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	platforms, err := loadPlatforms(*flags.platformsFile)
+	if err != nil {
+		log.Printf("Falling back to built-in platforms (%v)", err)
+		platforms = initializePlatforms()
+	}
+
+	scraper := NewJobScraper(platforms, configData)
+	scheduler := NewScheduler(scraper.redis)
+	scraper.scheduler = scheduler
+
+	if len(scraper.sinks) == 0 {
+		fallback, err := newCSVSink(*flags.outputFile)
+		if err != nil {
+			log.Fatalf("Failed to open fallback CSV sink %s: %v", *flags.outputFile, err)
+		}
+		scraper.sinks = append(scraper.sinks, fallback)
+	}
+	defer func() {
+		for _, sink := range scraper.sinks {
+			if err := sink.Flush(); err != nil {
+				log.Printf("Failed to flush sink: %v", err)
+			}
+			if err := sink.Close(); err != nil {
+				log.Printf("Failed to close sink: %v", err)
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signalChan
+		cancel()
+	}()
+
+	dashboardAddr := *flags.dashboardAddr
+	if dashboardAddr == "" {
+		dashboardAddr = configData.DashboardAddr
+	}
+	if dashboardAddr != "" {
+		go func() {
+			if err := scraper.Serve(ctx, dashboardAddr); err != nil {
+				log.Printf("Dashboard server failed: %v", err)
+			}
+		}()
+	}
+
+	for _, platform := range platforms {
+		payload := ScrapeJobPayload{Platform: platform.Name, JobTitle: *flags.jobTitle, Location: *flags.location}
+		if _, err := scheduler.EnqueueJob(ctx, payload, 0, *flags.interval); err != nil {
+			log.Fatalf("Failed to enqueue recurring job for %s: %v", platform.Name, err)
+		}
+	}
+
+	NewWorkerPool(scheduler, scraper, *flags.workers).Run(ctx)
+}
+
+// runInit implements the "init" subcommand: it interactively prompts for
+// environment, dashboard address, Redis URL, notifier credentials, and
+// which platforms to enable, then writes config.json and platforms.json.
+func runInit(args []string) {
+	fs := flag.NewFlagSet("init", flag.ExitOnError)
+	force := fs.Bool("force", false, "Overwrite config.json/platforms.json if they already exist")
+	configFile := fs.String("config", "config.json", "Path to write the generated configuration to")
+	platformsFile := fs.String("platforms", "platforms.json", "Path to write the generated platform list to")
+	fs.Parse(args)
+
+	if !*force {
+		for _, path := range []string{*configFile, *platformsFile} {
+			if _, err := os.Stat(path); err == nil {
+				log.Fatalf("%s already exists; pass -force to overwrite", path)
+			}
+		}
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	environment := prompt(reader, "Environment (development/production)", "development")
+	dashboardAddr := prompt(reader, "Dashboard listen address", ":8080")
+	redisURL := prompt(reader, "Redis URL", "localhost:6379")
+
+	notifiers := promptNotifiers(reader)
+	enabled := promptPlatforms(reader)
+
+	config := map[string]interface{}{
+		"_comment":         fmt.Sprintf("Generated by `scraper init` for the %s environment - edit freely.", environment),
+		"dashboard_addr":   dashboardAddr,
+		"redis_url":        redisURL,
+		"proxy_list":       []string{},
+		"notifiers":        notifiers,
+		"whatsapp_api_key": "",
+		"whatsapp_number":  "",
+	}
+
+	if err := writeJSONFile(*configFile, config); err != nil {
+		log.Fatalf("Failed to write %s: %v", *configFile, err)
+	}
+	if err := writeJSONFile(*platformsFile, enabled); err != nil {
+		log.Fatalf("Failed to write %s: %v", *platformsFile, err)
+	}
+
+	fmt.Printf("Wrote %s and %s\n", *configFile, *platformsFile)
+}
+
+func promptNotifiers(reader *bufio.Reader) []NotifierConfig {
+	notifierType := prompt(reader, "Notifier type (whatsapp/slack/discord/http/email/none)", "none")
+
+	switch notifierType {
+	case "whatsapp":
+		token := prompt(reader, "WhatsApp API key", "")
+		number := prompt(reader, "WhatsApp number", "")
+		return []NotifierConfig{{Type: "whatsapp", Name: "whatsapp", Token: token, Number: number}}
+	case "slack", "discord":
+		url := prompt(reader, notifierType+" webhook URL", "")
+		return []NotifierConfig{{Type: notifierType, Name: notifierType, URL: url}}
+	case "http":
+		url := prompt(reader, "HTTP endpoint URL", "")
+		return []NotifierConfig{{Type: "http", Name: "http", URL: url}}
+	case "email":
+		smtpAddr := prompt(reader, "SMTP address (host:port)", "")
+		from := prompt(reader, "From email", "")
+		to := prompt(reader, "To email", "")
+		return []NotifierConfig{{Type: "email", Name: "email", SMTPAddr: smtpAddr, FromEmail: from, ToEmail: to}}
+	default:
+		return nil
+	}
+}
+
+func promptPlatforms(reader *bufio.Reader) []Platform {
+	var enabled []Platform
+	for _, p := range initializePlatforms() {
+		if promptYesNo(reader, fmt.Sprintf("Enable %s?", p.Name), true) {
+			enabled = append(enabled, p)
+		}
+	}
+	if len(enabled) == 0 {
+		enabled = initializePlatforms()
+	}
+	return enabled
+}
+
+func prompt(reader *bufio.Reader, label, defaultValue string) string {
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+
+	line, _ := reader.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return defaultValue
+	}
+	return line
+}
+
+func promptYesNo(reader *bufio.Reader, label string, defaultValue bool) bool {
+	defaultLabel := "Y/n"
+	if !defaultValue {
+		defaultLabel = "y/N"
+	}
+	fmt.Printf("%s [%s]: ", label, defaultLabel)
+
+	line, _ := reader.ReadString('\n')
+	line = strings.ToLower(strings.TrimSpace(line))
+	if line == "" {
+		return defaultValue
+	}
+	return line == "y" || line == "yes"
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jobEventBus fans out every new Job to every live subscriber. The SSE
+// endpoint is its only subscriber: the notifier fan-out does not read from
+// the bus, and Scrape calls notify() directly for the same job it publishes.
+type jobEventBus struct {
+	mu   sync.Mutex
+	subs map[chan Job]struct{}
+}
+
+func newJobEventBus() *jobEventBus {
+	return &jobEventBus{subs: make(map[chan Job]struct{})}
+}
+
+func (b *jobEventBus) subscribe() chan Job {
+	ch := make(chan Job, 16)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *jobEventBus) unsubscribe(ch chan Job) {
+	b.mu.Lock()
+	delete(b.subs, ch)
+	b.mu.Unlock()
+	close(ch)
+}
+
+// publish delivers job to every subscriber without blocking; a subscriber
+// too slow to keep its buffer drained just misses the update.
+func (b *jobEventBus) publish(job Job) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- job:
+		default:
+		}
+	}
+}
+
+// scraperStats accumulates the counters GET /api/stats reports: jobs
+// scraped per platform, dedup hit rate, rate-limit waits, and notifier
+// outcomes.
+type scraperStats struct {
+	mu              sync.Mutex
+	platformScraped map[string]int64
+	dedupHits       int64
+	dedupMisses     int64
+	rateLimitWaits  int64
+	notifySuccess   int64
+	notifyFailure   int64
+}
+
+func newScraperStats() *scraperStats {
+	return &scraperStats{platformScraped: make(map[string]int64)}
+}
+
+func (s *scraperStats) recordScraped(platform string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.platformScraped[platform]++
+}
+
+func (s *scraperStats) recordDedup(isNew bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if isNew {
+		s.dedupMisses++
+	} else {
+		s.dedupHits++
+	}
+}
+
+func (s *scraperStats) recordRateLimitWait() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimitWaits++
+}
+
+func (s *scraperStats) recordNotify(success bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if success {
+		s.notifySuccess++
+	} else {
+		s.notifyFailure++
+	}
+}
+
+// statsSnapshot is the JSON shape returned by GET /api/stats.
+type statsSnapshot struct {
+	PlatformScraped map[string]int64 `json:"platform_scraped"`
+	DedupHits       int64            `json:"dedup_hits"`
+	DedupMisses     int64            `json:"dedup_misses"`
+	RateLimitWaits  int64            `json:"rate_limit_waits"`
+	NotifySuccess   int64            `json:"notify_success"`
+	NotifyFailure   int64            `json:"notify_failure"`
+}
+
+func (s *scraperStats) snapshot() statsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	platformScraped := make(map[string]int64, len(s.platformScraped))
+	for k, v := range s.platformScraped {
+		platformScraped[k] = v
+	}
+
+	return statsSnapshot{
+		PlatformScraped: platformScraped,
+		DedupHits:       s.dedupHits,
+		DedupMisses:     s.dedupMisses,
+		RateLimitWaits:  s.rateLimitWaits,
+		NotifySuccess:   s.notifySuccess,
+		NotifyFailure:   s.notifyFailure,
+	}
+}
+
+// scrapeRequest is the body POST /api/scrape expects.
+type scrapeRequest struct {
+	Platform          string `json:"platform"`
+	JobTitle          string `json:"job_title"`
+	Location          string `json:"location"`
+	RepeatEverySecond int    `json:"repeat_every_seconds"`
+}
+
+// Serve starts the dashboard HTTP server on addr and blocks until ctx is
+// cancelled, then shuts it down gracefully.
+func (js *JobScraper) Serve(ctx context.Context, addr string) error {
+	server := &http.Server{Addr: addr, Handler: js.dashboardMux()}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (js *JobScraper) dashboardMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", js.handleDashboardIndex)
+	mux.HandleFunc("/api/jobs", js.handleJobs)
+	mux.HandleFunc("/api/stats", js.handleStats)
+	mux.HandleFunc("/api/platforms", js.handlePlatforms)
+	mux.HandleFunc("/api/scrape", js.handleScrape)
+	mux.HandleFunc("/events", js.handleEvents)
+	return mux
+}
+
+func (js *JobScraper) handleDashboardIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html")
+	w.Write([]byte(dashboardIndexHTML))
+}
+
+// handleJobs serves GET /api/jobs, paginated and filterable by platform,
+// skill, and a minimum AddedAt date.
+func (js *JobScraper) handleJobs(w http.ResponseWriter, r *http.Request) {
+	platform := r.URL.Query().Get("platform")
+	skill := strings.ToLower(r.URL.Query().Get("skill"))
+	since := parseSinceParam(r.URL.Query().Get("since"))
+	limit := parseIntParam(r.URL.Query().Get("limit"), 50)
+	offset := parseIntParam(r.URL.Query().Get("offset"), 0)
+
+	js.recentJobsMutex.Lock()
+	matched := make([]Job, 0, len(js.recentJobs))
+	for _, job := range js.recentJobs {
+		if platform != "" && job.Platform != platform {
+			continue
+		}
+		if skill != "" && !hasSkill(job.Skills, skill) {
+			continue
+		}
+		if !since.IsZero() && job.AddedAt.Before(since) {
+			continue
+		}
+		matched = append(matched, job)
+	}
+	js.recentJobsMutex.Unlock()
+
+	total := len(matched)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"total": total,
+		"jobs":  matched[offset:end],
+	})
+}
+
+func hasSkill(skills []string, skill string) bool {
+	for _, s := range skills {
+		if strings.ToLower(s) == skill {
+			return true
+		}
+	}
+	return false
+}
+
+func parseSinceParam(raw string) time.Time {
+	if raw == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func parseIntParam(raw string, fallback int) int {
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return fallback
+	}
+	return n
+}
+
+func (js *JobScraper) handleStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, js.stats.snapshot())
+}
+
+func (js *JobScraper) handlePlatforms(w http.ResponseWriter, r *http.Request) {
+	type platformInfo struct {
+		Name      string `json:"name"`
+		BaseURL   string `json:"base_url"`
+		QueryPath string `json:"query_path"`
+	}
+
+	infos := make([]platformInfo, 0, len(js.platforms))
+	for _, p := range js.platforms {
+		infos = append(infos, platformInfo{Name: p.Name, BaseURL: p.BaseURL, QueryPath: p.QueryPath})
+	}
+	writeJSON(w, infos)
+}
+
+// handleScrape serves POST /api/scrape, enqueueing a new ScrapeJob on the
+// scraper's Scheduler so it runs on the next free Worker.
+func (js *JobScraper) handleScrape(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if js.scheduler == nil {
+		http.Error(w, "scheduler not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req scrapeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	found := false
+	for _, p := range js.platforms {
+		if p.Name == req.Platform {
+			found = true
+			break
+		}
+	}
+	if !found {
+		http.Error(w, fmt.Sprintf("unknown platform %q", req.Platform), http.StatusBadRequest)
+		return
+	}
+
+	payload := ScrapeJobPayload{Platform: req.Platform, JobTitle: req.JobTitle, Location: req.Location}
+	repeatEvery := time.Duration(req.RepeatEverySecond) * time.Second
+
+	id, err := js.scheduler.EnqueueJob(r.Context(), payload, 0, repeatEvery)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to enqueue job: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]string{"job_id": id})
+}
+
+// handleEvents serves GET /events, an SSE stream pushing each new Job as it
+// passes isNewJob, sourced from the jobEventBus Scrape publishes to.
+func (js *JobScraper) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := js.events.subscribe()
+	defer js.events.unsubscribe(ch)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case job, ok := <-ch:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(job)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write JSON response: %v", err)
+	}
+}
+
+const dashboardIndexHTML = `<!DOCTYPE html>
+<html>
+<head>
+	<title>getalljobs dashboard</title>
+	<style>
+		body { font-family: sans-serif; margin: 2rem; }
+		table { border-collapse: collapse; width: 100%; }
+		th, td { border: 1px solid #ccc; padding: 0.4rem 0.6rem; text-align: left; }
+		#stats { margin-bottom: 1rem; }
+	</style>
+</head>
+<body>
+	<h1>getalljobs</h1>
+	<pre id="stats">loading stats...</pre>
+	<table id="jobs"><thead><tr><th>Platform</th><th>Title</th><th>Company</th><th>Location</th></tr></thead><tbody></tbody></table>
+	<script>
+		function refreshStats() {
+			fetch('/api/stats').then(r => r.json()).then(s => {
+				document.getElementById('stats').textContent = JSON.stringify(s, null, 2);
+			});
+		}
+		function addRow(job) {
+			const row = document.getElementById('jobs').getElementsByTagName('tbody')[0].insertRow(0);
+			row.insertCell(0).textContent = job.Platform;
+			row.insertCell(1).textContent = job.Title;
+			row.insertCell(2).textContent = job.Company;
+			row.insertCell(3).textContent = job.Location;
+		}
+		refreshStats();
+		setInterval(refreshStats, 5000);
+		fetch('/api/jobs?limit=50').then(r => r.json()).then(d => (d.jobs || []).forEach(addRow));
+		const events = new EventSource('/events');
+		events.onmessage = e => addRow(JSON.parse(e.data));
+	</script>
+</body>
+</html>`
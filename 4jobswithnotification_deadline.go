@@ -0,0 +1,97 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// deadlineTimer bounds how long a single Scrape call may run, following the
+// pattern net.Conn deadlines use internally: a timer closes a cancellation
+// channel when it fires, and resetting the deadline swaps in a fresh
+// channel if the old one has already been closed.
+type deadlineTimer struct {
+	mu            sync.Mutex
+	timer         *time.Timer
+	readCancelCh  chan struct{}
+	writeCancelCh chan struct{}
+	closed        bool
+}
+
+// newDeadlineTimer returns a deadlineTimer with no deadline set; its
+// cancellation channels never close until SetScrapeDeadline is called.
+func newDeadlineTimer() *deadlineTimer {
+	return &deadlineTimer{
+		readCancelCh:  make(chan struct{}),
+		writeCancelCh: make(chan struct{}),
+	}
+}
+
+// readCancel returns the channel Redis calls should select on to abort
+// when the scrape deadline passes.
+func (dt *deadlineTimer) readCancel() <-chan struct{} {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	return dt.readCancelCh
+}
+
+// writeCancel returns the channel the collector's OnRequest hook should
+// select on to abort outbound requests when the scrape deadline passes.
+func (dt *deadlineTimer) writeCancel() <-chan struct{} {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	return dt.writeCancelCh
+}
+
+// SetScrapeDeadline resets the deadline to t. A zero t disables it; a t
+// that has already passed closes the cancellation channels immediately.
+// Any previously set timer is stopped first, and if the channels are
+// already closed - whether because the timer fired or because a past
+// deadline closed them directly - fresh channels are allocated before the
+// new deadline is armed (or before returning, for a zero t).
+func (dt *deadlineTimer) SetScrapeDeadline(t time.Time) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	if dt.timer != nil {
+		if !dt.timer.Stop() {
+			// The timer already fired; its callback closes the current
+			// channels, possibly after this call returns.
+			dt.closed = true
+		}
+		dt.timer = nil
+	}
+	if dt.closed {
+		dt.readCancelCh = make(chan struct{})
+		dt.writeCancelCh = make(chan struct{})
+		dt.closed = false
+	}
+
+	if t.IsZero() {
+		return
+	}
+
+	delay := time.Until(t)
+	if delay <= 0 {
+		dt.closed = true
+		close(dt.readCancelCh)
+		close(dt.writeCancelCh)
+		return
+	}
+
+	readCh, writeCh := dt.readCancelCh, dt.writeCancelCh
+	dt.timer = time.AfterFunc(delay, func() {
+		dt.mu.Lock()
+		if dt.readCancelCh == readCh {
+			dt.closed = true
+		}
+		dt.mu.Unlock()
+		close(readCh)
+		close(writeCh)
+	})
+}
+
+// SetScrapeDeadline bounds how long the next Scrape call may run before its
+// Redis calls and outbound requests are cancelled.
+func (js *JobScraper) SetScrapeDeadline(t time.Time) {
+	js.deadline.SetScrapeDeadline(t)
+}
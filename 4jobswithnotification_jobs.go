@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Redis keys backing the durable scrape-job queue: scrapeJobsRecordsKey is a
+// hash of id -> JSON(ScrapeJob), scrapeJobsQueueKey is a list of ids ready
+// to run. Splitting the two lets a Worker BLPOP the queue for the next id
+// without reading (and re-writing) the whole record just to dequeue it.
+const (
+	scrapeJobsRecordsKey = "scrapejobs:records"
+	scrapeJobsQueueKey   = "scrapejobs:queue"
+)
+
+// ScrapeJobStatus enumerates a ScrapeJob's lifecycle.
+type ScrapeJobStatus string
+
+const (
+	ScrapeJobPending    ScrapeJobStatus = "pending"
+	ScrapeJobInProgress ScrapeJobStatus = "in_progress"
+	ScrapeJobSuccess    ScrapeJobStatus = "success"
+	ScrapeJobError      ScrapeJobStatus = "error"
+	ScrapeJobCanceled   ScrapeJobStatus = "canceled"
+)
+
+// ScrapeJobPayload names the platform/search a ScrapeJob runs.
+type ScrapeJobPayload struct {
+	Platform string `json:"platform"`
+	JobTitle string `json:"job_title"`
+	Location string `json:"location"`
+}
+
+// ScrapeJob is a single unit of scrape work whose record and queue entry are
+// persisted in Redis, so jobs still waiting in the queue survive a restart.
+// A job a Worker has already popped is not re-queued after a restart, and
+// neither is a recurring job's next run that is still waiting on its timer.
+type ScrapeJob struct {
+	ID        string           `json:"id"`
+	Type      string           `json:"type"` // "once" or "recurring"
+	Priority  int              `json:"priority"`
+	Status    ScrapeJobStatus  `json:"status"`
+	Payload   ScrapeJobPayload `json:"payload"`
+	NextRunAt time.Time        `json:"next_run_at"`
+	LastError string           `json:"last_error,omitempty"`
+	Attempts  int              `json:"attempts"`
+
+	// RepeatEvery reschedules a fresh ScrapeJob with the same payload once
+	// this one finishes, turning a single search into a recurring one.
+	RepeatEvery time.Duration `json:"repeat_every,omitempty"`
+}
+
+// Scheduler turns a (platform, title, location) search into a durable
+// ScrapeJob record in Redis, replacing the ad-hoc goroutine-per-platform
+// fan-out main used to run a crawl directly.
+type Scheduler struct {
+	redis  *redis.Client
+	nextID int64
+}
+
+// NewScheduler builds a Scheduler backed by redisClient.
+func NewScheduler(redisClient *redis.Client) *Scheduler {
+	return &Scheduler{redis: redisClient}
+}
+
+func (s *Scheduler) newJobID() string {
+	return fmt.Sprintf("job-%d-%d", time.Now().UnixNano(), atomic.AddInt64(&s.nextID, 1))
+}
+
+// EnqueueJob persists a new ScrapeJob for payload and pushes it onto the
+// ready queue. repeatEvery > 0 makes it recurring: a Worker reschedules a
+// fresh job with the same payload once this one finishes.
+func (s *Scheduler) EnqueueJob(ctx context.Context, payload ScrapeJobPayload, priority int, repeatEvery time.Duration) (string, error) {
+	job := ScrapeJob{
+		ID:          s.newJobID(),
+		Type:        "once",
+		Priority:    priority,
+		Status:      ScrapeJobPending,
+		Payload:     payload,
+		NextRunAt:   time.Now(),
+		RepeatEvery: repeatEvery,
+	}
+	if repeatEvery > 0 {
+		job.Type = "recurring"
+	}
+	return job.ID, s.saveAndQueue(ctx, job)
+}
+
+func (s *Scheduler) saveAndQueue(ctx context.Context, job ScrapeJob) error {
+	if err := s.save(ctx, job); err != nil {
+		return err
+	}
+	return s.redis.RPush(ctx, scrapeJobsQueueKey, job.ID).Err()
+}
+
+func (s *Scheduler) save(ctx context.Context, job ScrapeJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return s.redis.HSet(ctx, scrapeJobsRecordsKey, job.ID, data).Err()
+}
+
+func (s *Scheduler) getJob(ctx context.Context, id string) (ScrapeJob, error) {
+	data, err := s.redis.HGet(ctx, scrapeJobsRecordsKey, id).Result()
+	if err != nil {
+		return ScrapeJob{}, err
+	}
+	var job ScrapeJob
+	err = json.Unmarshal([]byte(data), &job)
+	return job, err
+}
+
+// CancelJob marks id canceled. A Worker that later dequeues it will see the
+// canceled status and skip running it.
+func (s *Scheduler) CancelJob(ctx context.Context, id string) error {
+	job, err := s.getJob(ctx, id)
+	if err != nil {
+		return err
+	}
+	job.Status = ScrapeJobCanceled
+	return s.save(ctx, job)
+}
+
+// ListJobs returns every persisted job, optionally filtered to a single
+// status ("" returns all of them).
+func (s *Scheduler) ListJobs(ctx context.Context, status ScrapeJobStatus) ([]ScrapeJob, error) {
+	raw, err := s.redis.HGetAll(ctx, scrapeJobsRecordsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]ScrapeJob, 0, len(raw))
+	for _, data := range raw {
+		var job ScrapeJob
+		if err := json.Unmarshal([]byte(data), &job); err != nil {
+			continue
+		}
+		if status == "" || job.Status == status {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+// WorkerPool pops ScrapeJobs off the Scheduler's Redis queue and runs them
+// against a JobScraper, rescheduling recurring jobs once they finish.
+type WorkerPool struct {
+	scheduler   *Scheduler
+	scraper     *JobScraper
+	platforms   map[string]Platform
+	concurrency int
+}
+
+// NewWorkerPool builds a WorkerPool with concurrency workers draining
+// scheduler's queue against scraper.
+func NewWorkerPool(scheduler *Scheduler, scraper *JobScraper, concurrency int) *WorkerPool {
+	platforms := make(map[string]Platform, len(scraper.platforms))
+	for _, p := range scraper.platforms {
+		platforms[p.Name] = p
+	}
+	return &WorkerPool{scheduler: scheduler, scraper: scraper, platforms: platforms, concurrency: concurrency}
+}
+
+// Run starts wp.concurrency workers, each blocking on the Redis queue, and
+// blocks itself until ctx is cancelled.
+func (wp *WorkerPool) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < wp.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wp.runWorker(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (wp *WorkerPool) runWorker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		result, err := wp.scheduler.redis.BLPop(ctx, 5*time.Second, scrapeJobsQueueKey).Result()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if err != redis.Nil {
+				log.Printf("Worker dequeue error: %v", err)
+			}
+			continue
+		}
+
+		// BLPop returns [key, value]; value is the job id.
+		wp.runJob(ctx, result[1])
+	}
+}
+
+func (wp *WorkerPool) runJob(ctx context.Context, jobID string) {
+	job, err := wp.scheduler.getJob(ctx, jobID)
+	if err != nil {
+		log.Printf("Worker failed to load job %s: %v", jobID, err)
+		return
+	}
+	if job.Status == ScrapeJobCanceled {
+		return
+	}
+
+	platform, ok := wp.platforms[job.Payload.Platform]
+	if !ok {
+		job.Status = ScrapeJobError
+		job.LastError = fmt.Sprintf("unknown platform %q", job.Payload.Platform)
+		if err := wp.scheduler.save(ctx, job); err != nil {
+			log.Printf("Failed to save job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	job.Status = ScrapeJobInProgress
+	job.Attempts++
+	if err := wp.scheduler.save(ctx, job); err != nil {
+		log.Printf("Failed to save job %s: %v", job.ID, err)
+	}
+
+	wp.scraper.Scrape(ctx, platform, job.Payload.JobTitle, job.Payload.Location)
+
+	job.Status = ScrapeJobSuccess
+	if err := wp.scheduler.save(ctx, job); err != nil {
+		log.Printf("Failed to save job %s: %v", job.ID, err)
+	}
+
+	if job.RepeatEvery > 0 {
+		next := job
+		next.ID = wp.scheduler.newJobID()
+		next.Status = ScrapeJobPending
+		next.Attempts = 0
+		next.LastError = ""
+		next.NextRunAt = time.Now().Add(job.RepeatEvery)
+
+		time.AfterFunc(job.RepeatEvery, func() {
+			if err := wp.scheduler.saveAndQueue(context.Background(), next); err != nil {
+				log.Printf("Failed to reschedule recurring job %s: %v", job.ID, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/smtp"
+	"sync"
+	"time"
+)
+
+// AttachmentField is a single labeled value within an Attachment, rendered
+// side-by-side with other Short fields by clients that support it.
+type AttachmentField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// Attachment mirrors Slack's message-attachment schema, so a Slack/Discord
+// notifier can serialize it directly while other notifiers fall back to
+// its Fallback text.
+type Attachment struct {
+	Fallback  string            `json:"fallback"`
+	Color     string            `json:"color,omitempty"`
+	Pretext   string            `json:"pretext,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	TitleLink string            `json:"title_link,omitempty"`
+	Text      string            `json:"text,omitempty"`
+	Fields    []AttachmentField `json:"fields,omitempty"`
+	Footer    string            `json:"footer,omitempty"`
+	Timestamp int64             `json:"ts,omitempty"`
+}
+
+// NotifyMessage is the sink-agnostic payload every Notifier receives.
+type NotifyMessage struct {
+	Text        string       `json:"text"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Notifier delivers a NotifyMessage to one destination.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, msg NotifyMessage) error
+}
+
+// jobNotifyMessage builds the Slack-attachment-shaped message for a single
+// new job, shared by every Notifier implementation.
+func jobNotifyMessage(job Job) NotifyMessage {
+	fallback := fmt.Sprintf("New job: %s at %s (%s) - %s", job.Title, job.Company, job.Location, job.URL)
+	return NotifyMessage{
+		Text: fallback,
+		Attachments: []Attachment{{
+			Fallback:  fallback,
+			Color:     "#36a64f",
+			Title:     job.Title,
+			TitleLink: job.URL,
+			Text:      job.Description,
+			Fields: []AttachmentField{
+				{Title: "Company", Value: job.Company, Short: true},
+				{Title: "Location", Value: job.Location, Short: true},
+				{Title: "Salary", Value: job.Salary, Short: true},
+				{Title: "Platform", Value: job.Platform, Short: true},
+			},
+			Footer:    "getalljobs",
+			Timestamp: job.AddedAt.Unix(),
+		}},
+	}
+}
+
+// NotifierConfig configures a single notification sink. Which fields are
+// read depends on Type.
+type NotifierConfig struct {
+	Type string `json:"type"` // "whatsapp", "slack", "discord", "http", or "email"
+	Name string `json:"name"`
+
+	// URL is the webhook/endpoint for "slack", "discord", and "http".
+	URL string `json:"url,omitempty"`
+	// Token and Number are the WhatsApp Business API credentials for "whatsapp".
+	Token  string `json:"token,omitempty"`
+	Number string `json:"number,omitempty"`
+	// SMTPAddr, FromEmail, and ToEmail configure "email".
+	SMTPAddr  string `json:"smtp_addr,omitempty"`
+	FromEmail string `json:"from_email,omitempty"`
+	ToEmail   string `json:"to_email,omitempty"`
+}
+
+// buildNotifiers constructs every Notifier listed in config.Notifiers. With
+// no explicit list but legacy top-level WhatsApp credentials set, it
+// synthesizes a single WhatsApp notifier so existing config.json files keep
+// working unchanged.
+func buildNotifiers(config ConfigData) []Notifier {
+	specs := config.Notifiers
+	if len(specs) == 0 && config.WhatsappAPIKey != "" && config.WhatsappNumber != "" {
+		specs = []NotifierConfig{{Type: "whatsapp", Name: "whatsapp", Token: config.WhatsappAPIKey, Number: config.WhatsappNumber}}
+	}
+
+	var notifiers []Notifier
+	for _, spec := range specs {
+		notifier, err := newNotifier(spec)
+		if err != nil {
+			log.Printf("Skipping notifier %q: %v", spec.Name, err)
+			continue
+		}
+		notifiers = append(notifiers, notifier)
+	}
+	return notifiers
+}
+
+func newNotifier(spec NotifierConfig) (Notifier, error) {
+	name := spec.Name
+	if name == "" {
+		name = spec.Type
+	}
+
+	switch spec.Type {
+	case "whatsapp":
+		if spec.Token == "" || spec.Number == "" {
+			return nil, fmt.Errorf("whatsapp notifier requires token and number")
+		}
+		return &WhatsAppNotifier{name: name, apiKey: spec.Token, number: spec.Number}, nil
+	case "slack", "discord":
+		if spec.URL == "" {
+			return nil, fmt.Errorf("%s notifier requires a webhook url", spec.Type)
+		}
+		return &WebhookNotifier{name: name, url: spec.URL}, nil
+	case "http":
+		if spec.URL == "" {
+			return nil, fmt.Errorf("http notifier requires a url")
+		}
+		return &HTTPNotifier{name: name, url: spec.URL}, nil
+	case "email":
+		if spec.SMTPAddr == "" || spec.FromEmail == "" || spec.ToEmail == "" {
+			return nil, fmt.Errorf("email notifier requires smtp_addr, from_email, and to_email")
+		}
+		return &EmailNotifier{name: name, smtpAddr: spec.SMTPAddr, from: spec.FromEmail, to: spec.ToEmail}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type %q", spec.Type)
+	}
+}
+
+// WhatsAppNotifier sends NotifyMessage.Text through the WhatsApp Business
+// API, the same endpoint the old sendWhatsAppMessage method used.
+type WhatsAppNotifier struct {
+	name   string
+	apiKey string
+	number string
+}
+
+func (n *WhatsAppNotifier) Name() string { return n.name }
+
+func (n *WhatsAppNotifier) Notify(ctx context.Context, msg NotifyMessage) error {
+	payload := map[string]interface{}{
+		"phone":   n.number,
+		"message": msg.Text,
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.whatsapp.com/v1/messages", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+n.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("whatsapp API returned status: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// WebhookNotifier posts msg as a Slack-compatible incoming-webhook payload,
+// which Discord's Slack-compatible webhook endpoint also accepts.
+type WebhookNotifier struct {
+	name string
+	url  string
+}
+
+func (n *WebhookNotifier) Name() string { return n.name }
+
+func (n *WebhookNotifier) Notify(ctx context.Context, msg NotifyMessage) error {
+	return postJSON(ctx, n.url, map[string]interface{}{
+		"text":        msg.Text,
+		"attachments": msg.Attachments,
+	})
+}
+
+// HTTPNotifier POSTs the raw NotifyMessage as JSON, for sinks with no
+// particular schema expectations.
+type HTTPNotifier struct {
+	name string
+	url  string
+}
+
+func (n *HTTPNotifier) Name() string { return n.name }
+
+func (n *HTTPNotifier) Notify(ctx context.Context, msg NotifyMessage) error {
+	return postJSON(ctx, n.url, msg)
+}
+
+func postJSON(ctx context.Context, url string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// EmailNotifier sends msg's fallback text as a plaintext email over SMTP.
+type EmailNotifier struct {
+	name     string
+	smtpAddr string
+	from     string
+	to       string
+}
+
+func (n *EmailNotifier) Name() string { return n.name }
+
+func (n *EmailNotifier) Notify(ctx context.Context, msg NotifyMessage) error {
+	body := fmt.Sprintf("To: %s\r\nFrom: %s\r\nSubject: New job alert\r\n\r\n%s\r\n", n.to, n.from, msg.Text)
+	return smtp.SendMail(n.smtpAddr, nil, n.from, []string{n.to}, []byte(body))
+}
+
+// notifyMaxRetries and notifyBackoffBase bound each sink's retries before
+// its failure is logged; the other sinks run concurrently and are unaffected.
+const (
+	notifyMaxRetries  = 3
+	notifyBackoffBase = 500 * time.Millisecond
+)
+
+// notify fans msg out to every configured Notifier concurrently. Each sink
+// retries independently with exponential backoff; a failing sink is logged
+// and never blocks or fails the others.
+func (js *JobScraper) notify(ctx context.Context, job Job) {
+	if len(js.notifiers) == 0 {
+		return
+	}
+
+	msg := jobNotifyMessage(job)
+
+	var wg sync.WaitGroup
+	for _, notifier := range js.notifiers {
+		wg.Add(1)
+		go func(n Notifier) {
+			defer wg.Done()
+			if err := notifyWithRetry(ctx, n, msg); err != nil {
+				js.stats.recordNotify(false)
+				log.Printf("Notifier %s failed after retries: %v", n.Name(), err)
+			} else {
+				js.stats.recordNotify(true)
+			}
+		}(notifier)
+	}
+	wg.Wait()
+}
+
+func notifyWithRetry(ctx context.Context, n Notifier, msg NotifyMessage) error {
+	var lastErr error
+	for attempt := 0; attempt <= notifyMaxRetries; attempt++ {
+		if err := n.Notify(ctx, msg); err == nil {
+			return nil
+		} else {
+			lastErr = err
+		}
+
+		if attempt == notifyMaxRetries {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(notifyBackoffBase * time.Duration(1<<attempt)):
+		}
+	}
+	return lastErr
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// Sink persists scraped Jobs somewhere durable. Scrape calls Write from its
+// OnHTML callback for every new job instead of accumulating an in-memory
+// slice, so a long-running daemon's memory stays bounded.
+type Sink interface {
+	Write(ctx context.Context, job Job) error
+	Flush() error
+	Close() error
+}
+
+// SinkConfig configures a single output sink. Which fields are read depends
+// on Type.
+type SinkConfig struct {
+	Type string `json:"type"` // "csv", "jsonl", "sqlite", or "parquet"
+	Path string `json:"path,omitempty"`
+	DSN  string `json:"dsn,omitempty"`
+}
+
+// buildSinks constructs every Sink listed in config.Sinks, skipping (and
+// logging) any with an invalid spec. An empty list is valid: runScrape
+// falls back to a single CSV sink at its -output path.
+func buildSinks(config ConfigData) []Sink {
+	var sinks []Sink
+	for _, spec := range config.Sinks {
+		sink, err := newSink(spec)
+		if err != nil {
+			log.Printf("Skipping sink %q: %v", spec.Type, err)
+			continue
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks
+}
+
+func newSink(spec SinkConfig) (Sink, error) {
+	switch spec.Type {
+	case "csv":
+		if spec.Path == "" {
+			return nil, fmt.Errorf("csv sink requires a path")
+		}
+		return newCSVSink(spec.Path)
+	case "jsonl":
+		if spec.Path == "" {
+			return nil, fmt.Errorf("jsonl sink requires a path")
+		}
+		return newJSONLSink(spec.Path)
+	case "sqlite":
+		if spec.DSN == "" {
+			return nil, fmt.Errorf("sqlite sink requires a dsn")
+		}
+		return newSQLiteSink(spec.DSN)
+	case "parquet":
+		if spec.Path == "" {
+			return nil, fmt.Errorf("parquet sink requires a path")
+		}
+		return newParquetSink(spec.Path)
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", spec.Type)
+	}
+}
+
+var csvSinkHeader = []string{"Platform", "Title", "Company", "Location", "Salary",
+	"Posted Date", "URL", "Skills", "Added At"}
+
+// CSVSink appends one row per job, flushing after every write so a crash
+// loses at most the in-flight row.
+type CSVSink struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *csv.Writer
+}
+
+func newCSVSink(path string) (*CSVSink, error) {
+	_, statErr := os.Stat(path)
+	fileExists := statErr == nil
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &CSVSink{file: file, writer: csv.NewWriter(file)}
+	if !fileExists {
+		if err := sink.writer.Write(csvSinkHeader); err != nil {
+			file.Close()
+			return nil, err
+		}
+		sink.writer.Flush()
+	}
+	return sink, nil
+}
+
+func (s *CSVSink) Write(ctx context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := []string{
+		job.Platform,
+		job.Title,
+		job.Company,
+		job.Location,
+		job.Salary,
+		job.PostedDate,
+		job.URL,
+		strings.Join(job.Skills, "|"),
+		job.AddedAt.Format(time.RFC3339),
+	}
+	if err := s.writer.Write(record); err != nil {
+		return err
+	}
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+func (s *CSVSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+func (s *CSVSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writer.Flush()
+	return s.file.Close()
+}
+
+// JSONLSink appends one JSON-encoded job per line, syncing the file after
+// every write so a crash never loses an already-written job.
+type JSONLSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newJSONLSink(path string) (*JSONLSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLSink{file: file}, nil
+}
+
+func (s *JSONLSink) Write(ctx context.Context, job Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(data); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+func (s *JSONLSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Sync()
+}
+
+func (s *JSONLSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// SQLiteSink upserts each job (keyed on job.ID) into a jobs table, with its
+// skills normalized into a join table.
+type SQLiteSink struct {
+	mu sync.Mutex
+	db *sql.DB
+}
+
+func newSQLiteSink(dsn string) (*SQLiteSink, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := `
+CREATE TABLE IF NOT EXISTS jobs (
+	id TEXT PRIMARY KEY,
+	platform TEXT,
+	title TEXT,
+	company TEXT,
+	location TEXT,
+	description TEXT,
+	salary TEXT,
+	posted_date TEXT,
+	url TEXT,
+	added_at TEXT
+);
+CREATE TABLE IF NOT EXISTS job_skills (
+	job_id TEXT REFERENCES jobs(id),
+	skill TEXT,
+	PRIMARY KEY (job_id, skill)
+);`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteSink{db: db}, nil
+}
+
+func (s *SQLiteSink) Write(ctx context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO jobs (id, platform, title, company, location, description, salary, posted_date, url, added_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			platform=excluded.platform, title=excluded.title, company=excluded.company,
+			location=excluded.location, description=excluded.description, salary=excluded.salary,
+			posted_date=excluded.posted_date, url=excluded.url, added_at=excluded.added_at
+	`, job.ID, job.Platform, job.Title, job.Company, job.Location, job.Description,
+		job.Salary, job.PostedDate, job.URL, job.AddedAt.Format(time.RFC3339))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM job_skills WHERE job_id = ?`, job.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, skill := range job.Skills {
+		if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO job_skills (job_id, skill) VALUES (?, ?)`, job.ID, skill); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLiteSink) Flush() error { return nil }
+
+func (s *SQLiteSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Close()
+}
+
+// parquetJobRow is the flattened row shape ParquetSink writes; Skills is
+// pipe-joined since parquet-go's plain writer doesn't need a repeated field
+// for this scale of analytics data.
+type parquetJobRow struct {
+	ID          string `parquet:"name=id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Platform    string `parquet:"name=platform, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Title       string `parquet:"name=title, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Company     string `parquet:"name=company, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Location    string `parquet:"name=location, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Description string `parquet:"name=description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Salary      string `parquet:"name=salary, type=BYTE_ARRAY, convertedtype=UTF8"`
+	PostedDate  string `parquet:"name=posted_date, type=BYTE_ARRAY, convertedtype=UTF8"`
+	URL         string `parquet:"name=url, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Skills      string `parquet:"name=skills, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AddedAt     string `parquet:"name=added_at, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// ParquetSink batches jobs into a row-group-buffered Parquet file for bulk
+// analytics; Flush forces the current row group to disk.
+type ParquetSink struct {
+	mu     sync.Mutex
+	file   source.ParquetFile
+	writer *writer.ParquetWriter
+}
+
+func newParquetSink(path string) (*ParquetSink, error) {
+	file, err := local.NewLocalFileWriter(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pw, err := writer.NewParquetWriter(file, new(parquetJobRow), 4)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	pw.RowGroupSize = 128 * 1024 * 1024
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+
+	return &ParquetSink{file: file, writer: pw}, nil
+}
+
+func (s *ParquetSink) Write(ctx context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row := parquetJobRow{
+		ID:          job.ID,
+		Platform:    job.Platform,
+		Title:       job.Title,
+		Company:     job.Company,
+		Location:    job.Location,
+		Description: job.Description,
+		Salary:      job.Salary,
+		PostedDate:  job.PostedDate,
+		URL:         job.URL,
+		Skills:      strings.Join(job.Skills, "|"),
+		AddedAt:     job.AddedAt.Format(time.RFC3339),
+	}
+	return s.writer.Write(row)
+}
+
+func (s *ParquetSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writer.Flush(true)
+}
+
+func (s *ParquetSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writer.WriteStop(); err != nil {
+		return err
+	}
+	return s.file.Close()
+}
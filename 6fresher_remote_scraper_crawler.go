@@ -0,0 +1,369 @@
+package main
+
+// Shared by both the CLI entrypoint (6fresher_remote_scraper.go, built
+// without -tags lambda) and the Lambda entrypoint
+// (6fresher_remote_scraper_lambda.go, built with -tags lambda): the
+// pagination/detail-link frontier has no build-specific logic, so it
+// carries no build tag and stays visible to whichever entrypoint is
+// compiled in.
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly/v2"
+	bolt "go.etcd.io/bbolt"
+)
+
+// LinkKind distinguishes a "next page" link, which re-enters its rule's own
+// list-page parsing, from a "detail" link, which is a single job posting
+// worth fetching to backfill a blank Description.
+type LinkKind string
+
+const (
+	LinkKindPage   LinkKind = "page"
+	LinkKindDetail LinkKind = "detail"
+)
+
+// Link is one URL discovered while parsing a list page, at a given crawl
+// depth relative to that rule's seed search URL.
+type Link struct {
+	URL   string
+	Depth int
+	Kind  LinkKind
+	Rule  string // the ScraperRule.Name that discovered this link
+}
+
+var frontierBucket = []byte("visited")
+
+// Frontier is a depth-limited pagination/detail-link queue backed by a
+// bbolt file, so a restart after a crash or ban resumes without
+// re-enqueuing links it already visited in a previous run.
+//
+// Enqueue is called from inside the same worker goroutines that drain
+// Links(), while they're processing a page's OnHTML callbacks. A bounded
+// channel fed by a blocking send would deadlock the moment a single page
+// yields more in-flight links than the channel's buffer - every worker
+// would be stuck pushing onto a full channel with nobody left to drain
+// it. Instead, Enqueue appends to an unbounded queue guarded by a mutex,
+// and a dispatcher goroutine feeds Links() from that queue one link at a
+// time, so a producer never blocks on a consumer.
+type Frontier struct {
+	db        *bolt.DB
+	maxDepth  int
+	links     chan Link
+	queueMu   sync.Mutex
+	queue     []Link
+	queued    chan struct{}
+	pending   sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewFrontier opens (or creates) dbPath and returns a Frontier that caps
+// enqueued links at maxDepth. workers sizes the dispatch channel's
+// buffer only; Enqueue itself never blocks regardless of how many links
+// are in flight.
+func NewFrontier(dbPath string, maxDepth, workers int) (*Frontier, error) {
+	db, err := bolt.Open(dbPath, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening frontier db %s: %w", dbPath, err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(frontierBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing frontier bucket: %w", err)
+	}
+
+	f := &Frontier{
+		db:       db,
+		maxDepth: maxDepth,
+		links:    make(chan Link, workers*4),
+		queued:   make(chan struct{}, 1),
+	}
+	go f.dispatch()
+	return f, nil
+}
+
+// dispatch moves links from the unbounded queue onto links, one at a
+// time, blocking only on the send to links - never on Enqueue.
+func (f *Frontier) dispatch() {
+	for range f.queued {
+		for {
+			f.queueMu.Lock()
+			if len(f.queue) == 0 {
+				f.queueMu.Unlock()
+				break
+			}
+			link := f.queue[0]
+			f.queue = f.queue[1:]
+			f.queueMu.Unlock()
+			f.links <- link
+		}
+	}
+}
+
+// normalizeLink resolves href against base and strips its fragment, so
+// "https://x.com/a#top" and "https://x.com/a" dedupe to the same entry.
+func normalizeLink(base, href string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(href)
+	if err != nil {
+		return "", err
+	}
+	resolved := baseURL.ResolveReference(ref)
+	resolved.Fragment = ""
+	return resolved.String(), nil
+}
+
+// Enqueue resolves href against base and, if it hasn't been seen before and
+// depth is within maxDepth, records it as visited in bbolt and pushes it
+// onto Links(). It's a no-op (not an error) for a link already seen or a
+// depth beyond maxDepth.
+func (f *Frontier) Enqueue(rule, base, href string, depth int, kind LinkKind) error {
+	if href == "" || depth > f.maxDepth {
+		return nil
+	}
+	resolved, err := normalizeLink(base, href)
+	if err != nil {
+		return fmt.Errorf("resolving link %q against %q: %w", href, base, err)
+	}
+
+	var isNew bool
+	err = f.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(frontierBucket)
+		key := []byte(resolved)
+		if b.Get(key) != nil {
+			return nil
+		}
+		isNew = true
+		return b.Put(key, []byte(time.Now().UTC().Format(time.RFC3339)))
+	})
+	if err != nil {
+		return fmt.Errorf("recording visited link: %w", err)
+	}
+	if !isNew {
+		return nil
+	}
+
+	f.pending.Add(1)
+	f.queueMu.Lock()
+	f.queue = append(f.queue, Link{URL: resolved, Depth: depth, Kind: kind, Rule: rule})
+	f.queueMu.Unlock()
+	select {
+	case f.queued <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Links returns the channel a worker pool should range over.
+func (f *Frontier) Links() <-chan Link {
+	return f.links
+}
+
+// QueueDepth returns the number of links currently waiting to be
+// dispatched or buffered in the channel, a cheap approximation of the
+// dashboard's reported queue depth.
+func (f *Frontier) QueueDepth() int {
+	f.queueMu.Lock()
+	waiting := len(f.queue)
+	f.queueMu.Unlock()
+	return waiting + len(f.links)
+}
+
+// Done marks one link as fully processed, including any further links its
+// processing caused to be enqueued.
+func (f *Frontier) Done() {
+	f.pending.Done()
+}
+
+// CloseWhenDrained closes Links() once every link enqueued so far has been
+// marked Done, so a ranging worker pool exits instead of blocking forever.
+// Call it only once every seed enqueue has already happened.
+func (f *Frontier) CloseWhenDrained() {
+	go func() {
+		f.pending.Wait()
+		f.closeOnce.Do(func() {
+			close(f.queued)
+			close(f.links)
+		})
+	}()
+}
+
+// Close releases the underlying bbolt file.
+func (f *Frontier) Close() error {
+	return f.db.Close()
+}
+
+// attachRuleHandlers wires rule's list-item extraction onto c, and - when
+// frontier is non-nil - also enqueues each listing's detail link plus the
+// page's own "next page" link (if rule.NextPageSelector is set) for the
+// frontier's worker pool to pick up.
+func (fjs *FresherJobScraper) attachRuleHandlers(c *colly.Collector, rule ScraperRule, depth int, frontier *Frontier) {
+	c.OnHTML(rule.ListItemSelector, func(e *colly.HTMLElement) {
+		title := extractField(e, rule, "title")
+		company := extractField(e, rule, "company")
+		if title == "" || company == "" {
+			return
+		}
+
+		location := extractField(e, rule, "location")
+		if location == "" {
+			location = "Remote"
+		}
+		description := extractField(e, rule, "description")
+
+		applyURL := extractField(e, rule, "applyURL")
+		if applyURL != "" && !strings.HasPrefix(applyURL, "http") {
+			applyURL = "https://" + rule.Domain + applyURL
+		}
+
+		isFresher := fjs.isFresherJob(title, description)
+		isRemote := fjs.isRemoteJob(title, location, description)
+		if !isFresher || !isRemote {
+			return
+		}
+
+		job := FresherJob{
+			Platform:    rule.Name,
+			Title:       title,
+			Company:     company,
+			Location:    location,
+			Description: description,
+			Salary:      extractField(e, rule, "salary"),
+			PostedDate:  extractField(e, rule, "postedDate"),
+			IsRemote:    isRemote,
+			IsFresher:   isFresher,
+			URL:         e.Request.URL.String(),
+			ApplyURL:    applyURL,
+			ScrapedAt:   time.Now(),
+		}
+
+		if fjs.skillExtractor != nil {
+			fjs.skillExtractor.Extract(&job)
+		}
+
+		fjs.jobsMutex.Lock()
+		fjs.jobs = append(fjs.jobs, job)
+		fjs.jobsMutex.Unlock()
+
+		fjs.writeJobToSinks(job)
+		fjs.dashboardStats.recordJob(rule.Name)
+
+		log.Printf("Found via %s rule: %s at %s", rule.Name, title, company)
+
+		if frontier != nil && applyURL != "" {
+			if err := frontier.Enqueue(rule.Name, e.Request.URL.String(), applyURL, depth, LinkKindDetail); err != nil {
+				log.Printf("%s: failed to enqueue detail link: %v", rule.Name, err)
+			}
+		}
+	})
+
+	if frontier != nil && rule.NextPageSelector != "" {
+		c.OnHTML(rule.NextPageSelector, func(e *colly.HTMLElement) {
+			href := e.Attr("href")
+			if err := frontier.Enqueue(rule.Name, e.Request.URL.String(), href, depth+1, LinkKindPage); err != nil {
+				log.Printf("%s: failed to enqueue next page: %v", rule.Name, err)
+			}
+		})
+	}
+
+	c.OnError(func(r *colly.Response, err error) {
+		fjs.dashboardStats.recordRequest(err)
+		log.Printf("%s rule error: %v", rule.Name, err)
+	})
+}
+
+// visitListPage fetches pageURL with the same rule handlers used for the
+// rule's seed search, so a "next page" link dequeued from the frontier is
+// parsed identically to page one.
+func (fjs *FresherJobScraper) visitListPage(rule ScraperRule, pageURL string, depth int) error {
+	if fjs.isStopped(rule.Name) {
+		return nil
+	}
+	fjs.waitIfPaused(rule.Name)
+
+	c := fjs.createCollector()
+	fjs.attachRuleHandlers(c, rule, depth, fjs.frontier)
+
+	fjs.throttle.Wait(domainOf(pageURL))
+	if err := c.Visit(pageURL); err != nil {
+		return err
+	}
+	c.Wait()
+	return nil
+}
+
+// enrichDetailLink fetches a job posting's own detail page and, if the job
+// already recorded for that URL has no Description, backfills it from the
+// page's body text.
+func (fjs *FresherJobScraper) enrichDetailLink(detailURL string) {
+	resp, err := http.Get(detailURL)
+	if err != nil {
+		log.Printf("detail crawl: failed to fetch %s: %v", detailURL, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		log.Printf("detail crawl: failed to parse %s: %v", detailURL, err)
+		return
+	}
+
+	text := strings.TrimSpace(doc.Find("body").Text())
+	if len(text) > 2000 {
+		text = text[:2000]
+	}
+
+	fjs.jobsMutex.Lock()
+	defer fjs.jobsMutex.Unlock()
+	for i := range fjs.jobs {
+		if fjs.jobs[i].ApplyURL == detailURL && fjs.jobs[i].Description == "" {
+			fjs.jobs[i].Description = text
+		}
+	}
+}
+
+// drainFrontier runs `workers` goroutines over fjs.frontier.Links() until
+// CloseWhenDrained closes the channel, dispatching each link to the right
+// handler for its Kind.
+func (fjs *FresherJobScraper) drainFrontier(rules []ScraperRule, workers int) {
+	ruleByName := make(map[string]ScraperRule, len(rules))
+	for _, r := range rules {
+		ruleByName[r.Name] = r
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range fjs.frontier.Links() {
+				switch link.Kind {
+				case LinkKindDetail:
+					fjs.enrichDetailLink(link.URL)
+				case LinkKindPage:
+					if rule, ok := ruleByName[link.Rule]; ok {
+						if err := fjs.visitListPage(rule, link.URL, link.Depth); err != nil {
+							log.Printf("%s: failed to visit page %s: %v", rule.Name, link.URL, err)
+						}
+					}
+				}
+				fjs.frontier.Done()
+			}
+		}()
+	}
+	wg.Wait()
+}
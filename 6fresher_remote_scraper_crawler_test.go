@@ -0,0 +1,41 @@
+//go:build !lambda
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestFrontierEnqueueDoesNotDeadlockWhenQueueExceedsWorkerBuffer makes
+// workers*4*5 Enqueue calls from a single goroutine, covering 26 distinct
+// links - more than the worker pool's channel buffer (workers*4) - the way
+// one job-listing page can yield dozens of detail links. A
+// blocking send on a full f.links channel would hang forever here, since
+// nothing is draining it until Enqueue returns.
+func TestFrontierEnqueueDoesNotDeadlockWhenQueueExceedsWorkerBuffer(t *testing.T) {
+	const workers = 4
+	dbPath := filepath.Join(t.TempDir(), "frontier.db")
+	f, err := NewFrontier(dbPath, 3, workers)
+	if err != nil {
+		t.Fatalf("NewFrontier: %v", err)
+	}
+	defer f.Close()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < workers*4*5; i++ {
+			if err := f.Enqueue("rule", "https://example.com/", "/job/"+string(rune('a'+i%26)), 1, LinkKindDetail); err != nil {
+				t.Errorf("Enqueue: %v", err)
+			}
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Enqueue blocked - frontier deadlocked with no worker draining Links()")
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+// Shared by both the CLI entrypoint (6fresher_remote_scraper.go, built
+// without -tags lambda) and the Lambda entrypoint
+// (6fresher_remote_scraper_lambda.go, built with -tags lambda):
+// ScrapeAll starts this dashboard unconditionally whenever
+// FresherJobScraper.dashboardAddr is set, so it carries no build tag and
+// stays visible to whichever entrypoint is compiled in, even though the
+// Lambda entrypoint never sets dashboardAddr itself.
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+//go:embed dashboard/index.html
+var dashboardIndexHTML embed.FS
+
+// dashboardStats accumulates the counters GET /api/stats reports: jobs found
+// per platform (for jobs/min, counted over a trailing one-minute window) and
+// request outcomes (for error rate).
+type dashboardStats struct {
+	mu             sync.Mutex
+	startedAt      time.Time
+	platformCounts map[string]int64
+	recentJobTimes []time.Time
+	requestCount   int64
+	errorCount     int64
+}
+
+func newDashboardStats() *dashboardStats {
+	return &dashboardStats{startedAt: time.Now(), platformCounts: make(map[string]int64)}
+}
+
+// recordJob registers one job found for platform, for both the per-platform
+// count and the trailing jobs/min window.
+func (ds *dashboardStats) recordJob(platform string) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.platformCounts[platform]++
+
+	now := time.Now()
+	ds.recentJobTimes = append(ds.recentJobTimes, now)
+	cutoff := now.Add(-1 * time.Minute)
+	kept := ds.recentJobTimes[:0]
+	for _, t := range ds.recentJobTimes {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	ds.recentJobTimes = kept
+}
+
+// recordRequest registers one HTTP request's outcome, nil for success, for
+// the error-rate calculation.
+func (ds *dashboardStats) recordRequest(err error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.requestCount++
+	if err != nil {
+		ds.errorCount++
+	}
+}
+
+// dashboardSnapshot is the JSON shape returned by GET /api/stats.
+type dashboardSnapshot struct {
+	Platforms      map[string]int64 `json:"platforms"`
+	TotalJobs      int64            `json:"total_jobs"`
+	JobsPerMinute  int              `json:"jobs_per_minute"`
+	QueueDepth     int              `json:"queue_depth"`
+	ErrorRate      float64          `json:"error_rate"`
+	ElapsedSeconds float64          `json:"elapsed_seconds"`
+}
+
+func (ds *dashboardStats) snapshot(queueDepth int) dashboardSnapshot {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	platforms := make(map[string]int64, len(ds.platformCounts))
+	var total int64
+	for platform, count := range ds.platformCounts {
+		platforms[platform] = count
+		total += count
+	}
+
+	var errorRate float64
+	if ds.requestCount > 0 {
+		errorRate = float64(ds.errorCount) / float64(ds.requestCount)
+	}
+
+	cutoff := time.Now().Add(-1 * time.Minute)
+	jobsPerMinute := 0
+	for _, t := range ds.recentJobTimes {
+		if t.After(cutoff) {
+			jobsPerMinute++
+		}
+	}
+
+	return dashboardSnapshot{
+		Platforms:      platforms,
+		TotalJobs:      total,
+		JobsPerMinute:  jobsPerMinute,
+		QueueDepth:     queueDepth,
+		ErrorRate:      errorRate,
+		ElapsedSeconds: time.Since(ds.startedAt).Seconds(),
+	}
+}
+
+// dashboardControlRequest is the body POST /api/control expects.
+type dashboardControlRequest struct {
+	// Action is one of "pause", "resume", "stop" (all require Platform), or
+	// "set_query" (requires Titles).
+	Action   string   `json:"action"`
+	Platform string   `json:"platform,omitempty"`
+	Titles   []string `json:"titles,omitempty"`
+}
+
+// Serve starts the dashboard HTTP server on addr and blocks until ctx is
+// cancelled, then shuts it down gracefully.
+func (fjs *FresherJobScraper) Serve(ctx context.Context, addr string) error {
+	server := &http.Server{Addr: addr, Handler: fjs.dashboardMux()}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Dashboard listening on %s", addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (fjs *FresherJobScraper) dashboardMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", fjs.handleDashboardIndex)
+	mux.HandleFunc("/api/stats", fjs.handleDashboardStats)
+	mux.HandleFunc("/api/jobs", fjs.handleDashboardJobs)
+	mux.HandleFunc("/api/control", fjs.handleDashboardControl)
+	return mux
+}
+
+func (fjs *FresherJobScraper) handleDashboardIndex(w http.ResponseWriter, r *http.Request) {
+	data, err := dashboardIndexHTML.ReadFile("dashboard/index.html")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.Write(data)
+}
+
+// queueDepth reports fjs.frontier's buffered link count, or 0 when no
+// frontier is configured.
+func (fjs *FresherJobScraper) queueDepth() int {
+	if fjs.frontier == nil {
+		return 0
+	}
+	return fjs.frontier.QueueDepth()
+}
+
+func (fjs *FresherJobScraper) handleDashboardStats(w http.ResponseWriter, r *http.Request) {
+	writeDashboardJSON(w, fjs.dashboardStats.snapshot(fjs.queueDepth()))
+}
+
+// handleDashboardJobs serves GET /api/jobs, paginated and filterable by
+// platform and a minimum ScrapedAt.
+func (fjs *FresherJobScraper) handleDashboardJobs(w http.ResponseWriter, r *http.Request) {
+	platform := r.URL.Query().Get("platform")
+	since := parseDashboardSince(r.URL.Query().Get("since"))
+	limit := parseDashboardInt(r.URL.Query().Get("limit"), 50)
+	offset := parseDashboardInt(r.URL.Query().Get("offset"), 0)
+
+	fjs.jobsMutex.Lock()
+	matched := make([]FresherJob, 0, len(fjs.jobs))
+	for _, job := range fjs.jobs {
+		if platform != "" && job.Platform != platform {
+			continue
+		}
+		if !since.IsZero() && job.ScrapedAt.Before(since) {
+			continue
+		}
+		matched = append(matched, job)
+	}
+	fjs.jobsMutex.Unlock()
+
+	total := len(matched)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	writeDashboardJSON(w, map[string]interface{}{
+		"total": total,
+		"jobs":  matched[offset:end],
+	})
+}
+
+func parseDashboardSince(raw string) time.Time {
+	if raw == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func parseDashboardInt(raw string, fallback int) int {
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return fallback
+	}
+	return n
+}
+
+// handleDashboardControl serves POST /api/control: pause/resume/stop a
+// platform's workers, or swap the active search query, all taking effect at
+// the next checkpoint a worker polls rather than requiring a restart.
+func (fjs *FresherJobScraper) handleDashboardControl(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dashboardControlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	switch req.Action {
+	case "pause":
+		fjs.controlFor(req.Platform).paused.Store(true)
+	case "resume":
+		fjs.controlFor(req.Platform).paused.Store(false)
+	case "stop":
+		fjs.controlFor(req.Platform).stopped.Store(true)
+	case "set_query":
+		if len(req.Titles) == 0 {
+			http.Error(w, "titles is required for set_query", http.StatusBadRequest)
+			return
+		}
+		fjs.setActiveTitles(req.Titles)
+	default:
+		http.Error(w, fmt.Sprintf("unknown action %q", req.Action), http.StatusBadRequest)
+		return
+	}
+
+	writeDashboardJSON(w, map[string]string{"status": "ok"})
+}
+
+func writeDashboardJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to write dashboard JSON response: %v", err)
+	}
+}
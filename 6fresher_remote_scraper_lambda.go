@@ -0,0 +1,181 @@
+//go:build lambda
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+)
+
+// fresherLambdaRequest is the JSON body of an API Gateway proxy request.
+type fresherLambdaRequest struct {
+	Titles     []string `json:"titles"`
+	Platforms  []string `json:"platforms"`
+	MaxResults int      `json:"max_results"`
+}
+
+// fresherLambdaCacheEntry is a single warm-container cache hit.
+type fresherLambdaCacheEntry struct {
+	jobs      []FresherJob
+	scrapedAt time.Time
+}
+
+// fresherLambdaCacheTTL bounds how long a warm container reuses a previous
+// scrape for the same request instead of re-scraping - cold-scraping Indeed
+// on every HTTP call is both slow and a fast way to get the container's IP
+// banned.
+const fresherLambdaCacheTTL = 5 * time.Minute
+
+var (
+	fresherLambdaCacheMu sync.Mutex
+	fresherLambdaCache   = map[string]fresherLambdaCacheEntry{}
+)
+
+// fresherLambdaCacheKey normalizes req to a stable key: titles and platforms
+// are lowercased and sorted so two requests differing only in ordering still
+// hit the same warm-container entry.
+func fresherLambdaCacheKey(req fresherLambdaRequest) string {
+	titles := append([]string(nil), req.Titles...)
+	for i, t := range titles {
+		titles[i] = strings.ToLower(strings.TrimSpace(t))
+	}
+	sort.Strings(titles)
+
+	platforms := append([]string(nil), req.Platforms...)
+	for i, p := range platforms {
+		platforms[i] = strings.ToLower(strings.TrimSpace(p))
+	}
+	sort.Strings(platforms)
+
+	return strings.Join(titles, ",") + "|" + strings.Join(platforms, ",")
+}
+
+// filterFresherPlatforms keeps only jobs whose Platform is in platforms
+// (case-insensitive), or returns jobs unchanged if platforms is empty.
+func filterFresherPlatforms(jobs []FresherJob, platforms []string) []FresherJob {
+	if len(platforms) == 0 {
+		return jobs
+	}
+	allowed := make(map[string]bool, len(platforms))
+	for _, p := range platforms {
+		allowed[strings.ToLower(p)] = true
+	}
+
+	filtered := make([]FresherJob, 0, len(jobs))
+	for _, job := range jobs {
+		if allowed[strings.ToLower(job.Platform)] {
+			filtered = append(filtered, job)
+		}
+	}
+	return filtered
+}
+
+// fresherLambdaDeadline derives a hard timeout from the invocation's
+// remaining execution time, leaving a couple of seconds headroom to marshal
+// the response after ScrapeAll returns. It falls back to a fixed timeout
+// when Lambda hasn't attached a deadline (e.g. a local test invocation).
+func fresherLambdaDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithTimeout(ctx, 25*time.Second)
+	}
+	return context.WithDeadline(ctx, deadline.Add(-2*time.Second))
+}
+
+// runScrapeWithDeadline runs ScrapeAll to completion or until ctx is done,
+// whichever comes first. If ctx expires first, the scrape goroutine is left
+// to finish in the background rather than killed - colly has no mid-flight
+// cancellation hook here - so the abandoned scrape can keep appending to
+// fjs.jobs after this function has already returned ctx.Err().
+func runScrapeWithDeadline(ctx context.Context, fjs *FresherJobScraper, titles []string) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- fjs.ScrapeAll(titles)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fresherLambdaJSONResponse truncates jobs to maxResults (if positive) and
+// wraps them in an API Gateway proxy response.
+func fresherLambdaJSONResponse(jobs []FresherJob, maxResults int) (events.APIGatewayProxyResponse, error) {
+	if maxResults > 0 && len(jobs) > maxResults {
+		jobs = jobs[:maxResults]
+	}
+
+	body, err := json.Marshal(jobs)
+	if err != nil {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError, Body: err.Error()}, nil
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(body),
+	}, nil
+}
+
+// handleFresherLambdaRequest is the API Gateway proxy handler: it decodes
+// {titles, platforms, max_results} from the request body, serves a warm
+// cache hit when one is fresh enough, and otherwise runs ScrapeAll bounded
+// by the invocation's remaining execution time.
+func handleFresherLambdaRequest(ctx context.Context, gwReq events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req fresherLambdaRequest
+	if err := json.Unmarshal([]byte(gwReq.Body), &req); err != nil {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest, Body: fmt.Sprintf("invalid request body: %v", err)}, nil
+	}
+	if len(req.Titles) == 0 {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest, Body: "titles is required"}, nil
+	}
+
+	key := fresherLambdaCacheKey(req)
+
+	fresherLambdaCacheMu.Lock()
+	if entry, ok := fresherLambdaCache[key]; ok && time.Since(entry.scrapedAt) < fresherLambdaCacheTTL {
+		fresherLambdaCacheMu.Unlock()
+		return fresherLambdaJSONResponse(filterFresherPlatforms(entry.jobs, req.Platforms), req.MaxResults)
+	}
+	fresherLambdaCacheMu.Unlock()
+
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		log.Printf("fresher scrape request %s: titles=%v platforms=%v", lc.AwsRequestID, req.Titles, req.Platforms)
+	}
+
+	runCtx, cancel := fresherLambdaDeadline(ctx)
+	defer cancel()
+
+	scraper := NewFresherJobScraper()
+	if err := runScrapeWithDeadline(runCtx, scraper, req.Titles); err != nil && err != context.DeadlineExceeded {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError, Body: err.Error()}, nil
+	}
+
+	fresherLambdaCacheMu.Lock()
+	fresherLambdaCache[key] = fresherLambdaCacheEntry{jobs: scraper.jobs, scrapedAt: time.Now()}
+	fresherLambdaCacheMu.Unlock()
+
+	return fresherLambdaJSONResponse(filterFresherPlatforms(scraper.jobs, req.Platforms), req.MaxResults)
+}
+
+// main is the Lambda entrypoint. Build with build_fresher_lambda.sh, or
+// directly via:
+//
+//	GOOS=linux GOARCH=arm64 go build -tags lambda.norpc,lambda -o bootstrap .
+func main() {
+	lambda.Start(handleFresherLambdaRequest)
+}
@@ -0,0 +1,169 @@
+package main
+
+// Shared by both the CLI entrypoint (6fresher_remote_scraper.go, built
+// without -tags lambda) and the Lambda entrypoint
+// (6fresher_remote_scraper_lambda.go, built with -tags lambda): the proxy
+// pool has no build-specific logic, so it carries no build tag and stays
+// visible to whichever entrypoint is compiled in.
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+	collyproxy "github.com/gocolly/colly/v2/proxy"
+)
+
+// proxiesEnvVar lets a deployment supply proxies without a CLI flag, e.g. in
+// a container or cron job where flags are awkward to thread through.
+const proxiesEnvVar = "PROXIES"
+
+const (
+	// proxyConsecutiveErrorLimit is how many errors a proxy must serve in a
+	// row, not just accumulate overall, before it's ejected.
+	proxyConsecutiveErrorLimit = 3
+	proxyCooldown              = 2 * time.Minute
+)
+
+// resolveProxies returns the proxies named by the -proxies flag, falling
+// back to the comma-separated $PROXIES env var if the flag was empty.
+func resolveProxies(flagValue string) []string {
+	raw := flagValue
+	if raw == "" {
+		raw = os.Getenv(proxiesEnvVar)
+	}
+	if raw == "" {
+		return nil
+	}
+
+	var proxies []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
+
+// proxyHealth tracks one proxy's consecutive-error streak behind its own
+// mutex, since ejection is a run of N errors in a row rather than any single
+// bad response.
+type proxyHealth struct {
+	mu                sync.Mutex
+	consecutiveErrors int
+	cooldownUntil     time.Time
+}
+
+func (h *proxyHealth) isCoolingDown() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return time.Now().Before(h.cooldownUntil)
+}
+
+func (h *proxyHealth) reportSuccess() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.consecutiveErrors = 0
+}
+
+func (h *proxyHealth) reportError(limit int, cooldown time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.consecutiveErrors++
+	if h.consecutiveErrors >= limit {
+		h.consecutiveErrors = 0
+		h.cooldownUntil = time.Now().Add(cooldown)
+	}
+}
+
+// ProxyPool rotates a list of SOCKS5/HTTP proxy URLs via colly's
+// RoundRobinProxySwitcher, skipping over any proxy currently serving out a
+// cooldown after proxyConsecutiveErrorLimit errors in a row.
+type ProxyPool struct {
+	proxies  []string
+	rotate   colly.ProxyFunc
+	health   map[string]*proxyHealth
+	limit    int
+	cooldown time.Duration
+}
+
+// NewProxyPool builds a pool over proxies (http(s):// or socks5://,
+// optionally with embedded user:pass@ credentials). An empty list makes
+// Wrap return nil, so the caller skips SetProxyFunc and requests go out
+// directly.
+func NewProxyPool(proxies []string) (*ProxyPool, error) {
+	pool := &ProxyPool{proxies: proxies, limit: proxyConsecutiveErrorLimit, cooldown: proxyCooldown}
+	if len(proxies) == 0 {
+		return pool, nil
+	}
+
+	rotate, err := collyproxy.RoundRobinProxySwitcher(proxies...)
+	if err != nil {
+		return nil, fmt.Errorf("building proxy switcher: %w", err)
+	}
+	pool.rotate = rotate
+
+	pool.health = make(map[string]*proxyHealth, len(proxies))
+	for _, p := range proxies {
+		pool.health[p] = &proxyHealth{}
+	}
+	return pool, nil
+}
+
+// Wrap returns a colly.ProxyFunc that advances the round-robin switcher
+// until it lands on a proxy that isn't cooling down, trying at most
+// len(proxies) times before falling back to whatever the switcher last
+// returned. It returns nil if the pool has no proxies configured.
+func (p *ProxyPool) Wrap() colly.ProxyFunc {
+	if p == nil || p.rotate == nil {
+		return nil
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		var last *url.URL
+		for i := 0; i < len(p.proxies); i++ {
+			u, err := p.rotate(req)
+			if err != nil {
+				return nil, err
+			}
+			last = u
+			if !p.healthFor(u.String()).isCoolingDown() {
+				return u, nil
+			}
+		}
+		return last, nil
+	}
+}
+
+// healthFor looks up proxyURL's tracked health, which NewProxyPool
+// pre-populates for every configured proxy; it never inserts, so concurrent
+// calls need no locking around the map itself.
+func (p *ProxyPool) healthFor(proxyURL string) *proxyHealth {
+	if h, ok := p.health[proxyURL]; ok {
+		return h
+	}
+	return &proxyHealth{}
+}
+
+// ReportResult records an outbound request's outcome for proxyURL: a 403,
+// 429, or non-nil err extends its consecutive-error streak, ejecting it for
+// p.cooldown once that streak reaches p.limit; anything else resets it.
+func (p *ProxyPool) ReportResult(proxyURL string, statusCode int, err error) {
+	if p == nil || proxyURL == "" {
+		return
+	}
+
+	h := p.healthFor(proxyURL)
+	failed := err != nil || statusCode == http.StatusForbidden || statusCode == http.StatusTooManyRequests
+	if failed {
+		h.reportError(p.limit, p.cooldown)
+		return
+	}
+	h.reportSuccess()
+}
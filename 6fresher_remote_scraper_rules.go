@@ -0,0 +1,197 @@
+package main
+
+// Shared by both the CLI entrypoint (6fresher_remote_scraper.go, built
+// without -tags lambda) and the Lambda entrypoint
+// (6fresher_remote_scraper_lambda.go, built with -tags lambda): the
+// declarative rule engine has no build-specific logic, so it carries no
+// build tag and stays visible to whichever entrypoint is compiled in.
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// FieldRule describes how to pull one FresherJob field out of a list-item
+// element: Selector picks the element, Attr says whether to read its text
+// ("" or "text") or an attribute (e.g. "href"), and Regex, if set, is run
+// against the extracted value and replaces it with the first capture group
+// (or the whole match, if the regex has no group).
+type FieldRule struct {
+	Selector string `json:"selector"`
+	Attr     string `json:"attr,omitempty"`
+	Regex    string `json:"regex,omitempty"`
+}
+
+// ScraperRule declaratively describes one site: where to search and how to
+// pull a FresherJob out of each list-item on the results page. Loading rules
+// from disk (see LoadRulesDir) lets a new site be added without recompiling.
+type ScraperRule struct {
+	Name string `json:"name"`
+	// Domain is prefixed onto a field value extracted with Attr "href" that
+	// doesn't already start with "http".
+	Domain string `json:"domain"`
+	// SearchURLTemplate is the listing-page URL with "{query}" and
+	// "{location}" placeholders substituted per search.
+	SearchURLTemplate string `json:"searchURLTemplate"`
+	// ListItemSelector picks one job posting out of the results page; Fields
+	// is applied against each matched element.
+	ListItemSelector string               `json:"listItemSelector"`
+	Fields           map[string]FieldRule `json:"fields"`
+
+	// NextPageSelector, if set, picks the pagination link on a list page;
+	// ScrapeAll's Frontier follows it up to --max-depth instead of this
+	// rule only ever scraping page one.
+	NextPageSelector string `json:"nextPageSelector,omitempty"`
+}
+
+// builtinRules returns Indeed and RemoteOK as declarative rules, equivalent
+// to the hand-written scrapeIndeed/scrapeRemoteOK that
+// 6fresher_remote_scraper.go used to define.
+func builtinRules() []ScraperRule {
+	return []ScraperRule{
+		{
+			Name:              "Indeed",
+			Domain:            "www.indeed.com",
+			SearchURLTemplate: "https://www.indeed.com/jobs?q={query}&l={location}&explvl=entry_level&fromage=7",
+			ListItemSelector:  "[data-jk]",
+			Fields: map[string]FieldRule{
+				"title":       {Selector: "h2 a span"},
+				"company":     {Selector: "span.companyName"},
+				"location":    {Selector: "div.companyLocation"},
+				"description": {Selector: "div.job-snippet"},
+				"salary":      {Selector: "span.salaryText"},
+				"postedDate":  {Selector: "span.date"},
+				"applyURL":    {Selector: "h2 a", Attr: "href"},
+			},
+		},
+		{
+			Name:              "RemoteOK",
+			Domain:            "remoteok.io",
+			SearchURLTemplate: "https://remoteok.io/remote-dev-jobs?search={query}",
+			ListItemSelector:  "tr.job",
+			Fields: map[string]FieldRule{
+				"title":       {Selector: "td.company h2"},
+				"company":     {Selector: "td.company h3"},
+				"description": {Selector: "td.company .description"},
+				"salary":      {Selector: "td.salary"},
+				"applyURL":    {Selector: "td.company a", Attr: "href"},
+			},
+		},
+	}
+}
+
+// LoadRule reads a single JSON rule file.
+func LoadRule(path string) (ScraperRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ScraperRule{}, fmt.Errorf("reading rule file: %w", err)
+	}
+
+	var rule ScraperRule
+	if err := json.Unmarshal(data, &rule); err != nil {
+		return ScraperRule{}, fmt.Errorf("parsing rule file %s: %w", path, err)
+	}
+	return rule, nil
+}
+
+// LoadRulesDir loads every *.json file in dir into a ScraperRule slice.
+func LoadRulesDir(dir string) ([]ScraperRule, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading rules dir: %w", err)
+	}
+
+	var rules []ScraperRule
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		rule, err := LoadRule(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// extractField reads one FresherJob field out of e per rule.Fields[field],
+// applying the field's regex post-processor if one is set.
+func extractField(e *colly.HTMLElement, rule ScraperRule, field string) string {
+	fr, ok := rule.Fields[field]
+	if !ok {
+		return ""
+	}
+
+	var value string
+	if fr.Attr != "" && fr.Attr != "text" {
+		value = e.ChildAttr(fr.Selector, fr.Attr)
+	} else {
+		value = strings.TrimSpace(e.ChildText(fr.Selector))
+	}
+
+	if fr.Regex == "" {
+		return value
+	}
+	re, err := regexp.Compile(fr.Regex)
+	if err != nil {
+		return value
+	}
+	m := re.FindStringSubmatch(value)
+	if m == nil {
+		return value
+	}
+	if len(m) > 1 {
+		return m[1]
+	}
+	return m[0]
+}
+
+// scrapeWithRule runs one rule's search through the generic rule engine -
+// the same OnHTML/OnError/rate-limiting shape scrapeIndeed and
+// scrapeRemoteOK used to hand-write individually - re-reading
+// fjs.currentActiveTitles() on every iteration so a dashboard set_query
+// control call changes which titles are searched next without a restart.
+func (fjs *FresherJobScraper) scrapeWithRule(rule ScraperRule, jobTitles []string) error {
+	c := fjs.createCollector()
+	fjs.attachRuleHandlers(c, rule, 0, fjs.frontier)
+
+	for i := 0; ; i++ {
+		titles := fjs.currentActiveTitles()
+		if titles == nil {
+			titles = jobTitles
+		}
+		if i >= len(titles) {
+			break
+		}
+		if fjs.isStopped(rule.Name) {
+			log.Printf("%s: stopped via dashboard control", rule.Name)
+			break
+		}
+		fjs.waitIfPaused(rule.Name)
+
+		jobTitle := titles[i]
+		searchURL := strings.NewReplacer(
+			"{query}", url.QueryEscape(jobTitle),
+			"{location}", url.QueryEscape("Remote"),
+		).Replace(rule.SearchURLTemplate)
+
+		log.Printf("Scraping %s for: %s", rule.Name, jobTitle)
+
+		fjs.throttle.Wait(domainOf(searchURL))
+		if err := c.Visit(searchURL); err != nil {
+			log.Printf("Error visiting %s: %v", searchURL, err)
+		}
+	}
+
+	c.Wait()
+	return nil
+}
@@ -0,0 +1,46 @@
+//go:build !lambda
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// DumpRuleCandidates fetches targetURL and prints classes that repeat often
+// enough to plausibly be the list-item selector for a new rule file, per
+// --dump-rule.
+func DumpRuleCandidates(targetURL string) error {
+	resp, err := http.Get(targetURL)
+	if err != nil {
+		return fmt.Errorf("fetching %s: %w", targetURL, err)
+	}
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", targetURL, err)
+	}
+
+	classCounts := make(map[string]int)
+	doc.Find("*[class]").Each(func(_ int, s *goquery.Selection) {
+		class, _ := s.Attr("class")
+		for _, c := range strings.Fields(class) {
+			classCounts["."+c]++
+		}
+	})
+
+	fmt.Printf("Candidate list-item selectors for %s (classes repeated 3+ times):\n", targetURL)
+	for selector, count := range classCounts {
+		if count >= 3 {
+			fmt.Printf("  %-40s %d matches\n", selector, count)
+		}
+	}
+	fmt.Println("\nPick the selector that wraps one job listing as ListItemSelector, then add")
+	fmt.Println("field selectors (title/company/location/description/salary/postedDate/applyURL)")
+	fmt.Println("scoped beneath it to a rule JSON file.")
+	return nil
+}
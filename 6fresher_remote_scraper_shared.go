@@ -0,0 +1,387 @@
+package main
+
+// Shared by both the CLI entrypoint (6fresher_remote_scraper.go, built
+// without -tags lambda) and the Lambda entrypoint
+// (6fresher_remote_scraper_lambda.go, built with -tags lambda): the
+// FresherJob model and FresherJobScraper crawler itself have no
+// build-specific logic, so they carry no build tag and are visible to
+// whichever entrypoint is compiled in.
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// FresherJob represents a remote job suitable for freshers
+type FresherJob struct {
+	Platform    string
+	Title       string
+	Company     string
+	Location    string
+	Description string
+	Salary      string
+	PostedDate  string
+	IsRemote    bool
+	IsFresher   bool
+	URL         string
+	ApplyURL    string
+	Skills      FresherJobSkills
+	ScrapedAt   time.Time
+}
+
+// FresherJobScraper manages the scraping process
+type FresherJobScraper struct {
+	jobs            []FresherJob
+	jobsMutex       sync.Mutex
+	fresherKeywords []string
+	remoteKeywords  []string
+	excludeKeywords []string
+
+	// throttle replaces the old fixed rate.NewLimiter: it adapts each
+	// domain's request delay to that domain's own 429/403/200 responses
+	// instead of one hand-picked interval for every site.
+	throttle *DomainThrottle
+
+	// proxyPool, if it has any proxies configured, rotates outbound requests
+	// through them and ejects one that's erroring repeatedly. A pool with no
+	// proxies makes requests go out directly.
+	proxyPool *ProxyPool
+
+	// rulesDir, if set, is loaded by ScrapeAll in addition to builtinRules()
+	// so a site can be added via --rules without recompiling.
+	rulesDir string
+
+	// frontier, if set, makes ScrapeAll follow each rule's NextPageSelector
+	// and job detail links up to frontier.maxDepth instead of only ever
+	// scraping page one. nil disables pagination/detail crawling.
+	frontier        *Frontier
+	frontierWorkers int
+
+	// skillExtractor, if set, tags each job's Skills during scraping. nil
+	// leaves every job's Skills zero-valued.
+	skillExtractor *SkillExtractor
+
+	// requiredSkills, if non-empty, drops any job from writeJobToSinks whose
+	// Skills don't match every one of these (set via --require-skills).
+	requiredSkills []string
+
+	// sinks are written through as each job is discovered (see
+	// writeJobToSinks), rather than buffering the whole run and saving once
+	// at the end; sinksMutex guards concurrent Write calls from the
+	// goroutines attachRuleHandlers' OnHTML callbacks run on.
+	sinks      []OutputSink
+	sinksMutex sync.Mutex
+
+	// dashboardAddr, if set, makes ScrapeAll run an embedded HTTP dashboard
+	// (see Serve) on this address for the duration of the scrape.
+	dashboardAddr  string
+	dashboardStats *dashboardStats
+
+	// activeTitles holds the []string scrapeWithRule's per-title loop reads
+	// on each iteration; POST /api/control's set_query action swaps it at
+	// runtime, so a change takes effect without restarting the scrape.
+	activeTitles atomic.Value
+
+	// controls holds one platformControl per rule name, lazily created by
+	// controlFor, so POST /api/control can pause/resume/stop a platform's
+	// workers between requests.
+	controlsMutex sync.Mutex
+	controls      map[string]*platformControl
+}
+
+// platformControl is the shared runtime switch one rule's workers poll
+// between requests so dashboard control calls take effect quickly.
+type platformControl struct {
+	paused  atomic.Bool
+	stopped atomic.Bool
+}
+
+// controlFor returns platform's platformControl, creating it on first use.
+func (fjs *FresherJobScraper) controlFor(platform string) *platformControl {
+	fjs.controlsMutex.Lock()
+	defer fjs.controlsMutex.Unlock()
+
+	if fjs.controls == nil {
+		fjs.controls = make(map[string]*platformControl)
+	}
+	ctrl, ok := fjs.controls[platform]
+	if !ok {
+		ctrl = &platformControl{}
+		fjs.controls[platform] = ctrl
+	}
+	return ctrl
+}
+
+// waitIfPaused blocks while platform is paused, polling so a resume takes
+// effect quickly without a dedicated wakeup channel.
+func (fjs *FresherJobScraper) waitIfPaused(platform string) {
+	ctrl := fjs.controlFor(platform)
+	for ctrl.paused.Load() {
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// isStopped reports whether platform's workers should exit at the next
+// checkpoint.
+func (fjs *FresherJobScraper) isStopped(platform string) bool {
+	return fjs.controlFor(platform).stopped.Load()
+}
+
+// setActiveTitles replaces the query scrapeWithRule's next loop iteration
+// reads.
+func (fjs *FresherJobScraper) setActiveTitles(titles []string) {
+	fjs.activeTitles.Store(append([]string(nil), titles...))
+}
+
+// currentActiveTitles returns the most recently set active query, or nil if
+// none has been set yet.
+func (fjs *FresherJobScraper) currentActiveTitles() []string {
+	v := fjs.activeTitles.Load()
+	if v == nil {
+		return nil
+	}
+	return v.([]string)
+}
+
+// NewFresherJobScraper creates a new scraper instance
+func NewFresherJobScraper() *FresherJobScraper {
+	fresherKeywords := []string{
+		"entry level", "junior", "fresher", "graduate", "trainee", "intern",
+		"no experience", "0-1 years", "0-2 years", "recent graduate",
+		"entry-level", "beginner", "associate", "new grad", "starting",
+	}
+
+	remoteKeywords := []string{
+		"remote", "work from home", "telecommute", "distributed",
+		"home office", "anywhere", "location independent", "wfh",
+	}
+
+	excludeKeywords := []string{
+		"senior", "lead", "principal", "architect", "manager", "director",
+		"5+ years", "10+ years", "experienced", "expert", "specialist",
+	}
+
+	return &FresherJobScraper{
+		jobs:            []FresherJob{},
+		throttle:        NewDomainThrottle(),
+		proxyPool:       &ProxyPool{},
+		fresherKeywords: fresherKeywords,
+		remoteKeywords:  remoteKeywords,
+		excludeKeywords: excludeKeywords,
+		dashboardStats:  newDashboardStats(),
+	}
+}
+
+// getRandomUserAgent returns a random user agent
+func getRandomUserAgent() string {
+	agents := []string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36",
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36",
+	}
+	return agents[rand.Intn(len(agents))]
+}
+
+// createCollector creates a configured colly collector
+func (fjs *FresherJobScraper) createCollector() *colly.Collector {
+	c := colly.NewCollector(colly.Async(true))
+	c.UserAgent = getRandomUserAgent()
+
+	c.WithTransport(&http.Transport{
+		MaxIdleConns:    10,
+		IdleConnTimeout: 30 * time.Second,
+	})
+
+	c.OnRequest(func(r *colly.Request) {
+		r.Headers.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+		r.Headers.Set("Accept-Language", "en-US,en;q=0.5")
+	})
+
+	if wrap := fjs.proxyPool.Wrap(); wrap != nil {
+		c.SetProxyFunc(wrap)
+	}
+
+	c.OnResponse(func(r *colly.Response) {
+		fjs.dashboardStats.recordRequest(nil)
+		fjs.throttle.ReportStatus(domainOf(r.Request.URL.String()), r.StatusCode)
+		fjs.proxyPool.ReportResult(r.Request.ProxyURL, r.StatusCode, nil)
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		fjs.throttle.ReportStatus(domainOf(r.Request.URL.String()), r.StatusCode)
+		fjs.proxyPool.ReportResult(r.Request.ProxyURL, r.StatusCode, err)
+	})
+
+	c.Limit(&colly.LimitRule{
+		DomainGlob:  "*",
+		Parallelism: 2,
+	})
+
+	return c
+}
+
+// isFresherJob checks if a job is suitable for freshers
+func (fjs *FresherJobScraper) isFresherJob(title, description string) bool {
+	combined := strings.ToLower(title + " " + description)
+
+	// Check exclusions first
+	for _, keyword := range fjs.excludeKeywords {
+		if strings.Contains(combined, keyword) {
+			return false
+		}
+	}
+
+	// Check for fresher keywords
+	for _, keyword := range fjs.fresherKeywords {
+		if strings.Contains(combined, keyword) {
+			return true
+		}
+	}
+
+	// Check patterns
+	patterns := []string{
+		`0[\s-]?[12]?\s*years?`,
+		`entry[\s-]?level`,
+		`new[\s-]?grad`,
+	}
+
+	for _, pattern := range patterns {
+		matched, _ := regexp.MatchString(pattern, combined)
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRemoteJob checks if a job is remote
+func (fjs *FresherJobScraper) isRemoteJob(title, location, description string) bool {
+	combined := strings.ToLower(title + " " + location + " " + description)
+
+	for _, keyword := range fjs.remoteKeywords {
+		if strings.Contains(combined, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScrapeAll scrapes every registered ScraperRule (the builtin Indeed/RemoteOK
+// rules plus anything loaded from fjs.rulesDir) for remote fresher jobs,
+// instead of calling hand-written per-site scrape methods.
+func (fjs *FresherJobScraper) ScrapeAll(jobTitles []string) error {
+	log.Println("Starting Remote Fresher Jobs Scraper...")
+
+	fjs.setActiveTitles(jobTitles)
+
+	var dashboardCancel context.CancelFunc
+	if fjs.dashboardAddr != "" {
+		var dashboardCtx context.Context
+		dashboardCtx, dashboardCancel = context.WithCancel(context.Background())
+		go func() {
+			if err := fjs.Serve(dashboardCtx, fjs.dashboardAddr); err != nil {
+				log.Printf("dashboard server error: %v", err)
+			}
+		}()
+	}
+
+	rules := builtinRules()
+	if fjs.rulesDir != "" {
+		loaded, err := LoadRulesDir(fjs.rulesDir)
+		if err != nil {
+			log.Printf("could not load rules dir %q: %v", fjs.rulesDir, err)
+		} else {
+			rules = append(rules, loaded...)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, rule := range rules {
+		rule := rule
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fjs.scrapeWithRule(rule, jobTitles); err != nil {
+				log.Printf("%s scraping error: %v", rule.Name, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if fjs.frontier != nil {
+		fjs.frontier.CloseWhenDrained()
+		fjs.drainFrontier(rules, fjs.frontierWorkers)
+		if err := fjs.frontier.Close(); err != nil {
+			log.Printf("closing frontier db: %v", err)
+		}
+	}
+
+	if dashboardCancel != nil {
+		dashboardCancel()
+	}
+
+	log.Printf("Scraping completed. Found %d remote fresher jobs", len(fjs.jobs))
+	return nil
+}
+
+// writeJobToSinks streams job to every configured output sink as soon as
+// it's discovered, dropping it first if it doesn't satisfy fjs.requiredSkills
+// (set via --require-skills). A sink write error is logged rather than
+// aborting the scrape, since one bad sink shouldn't stop the others.
+func (fjs *FresherJobScraper) writeJobToSinks(job FresherJob) {
+	if len(fjs.requiredSkills) > 0 && !job.MatchesSkillFilter(fjs.requiredSkills, nil) {
+		return
+	}
+
+	fjs.sinksMutex.Lock()
+	defer fjs.sinksMutex.Unlock()
+	for _, sink := range fjs.sinks {
+		if err := sink.Write(job); err != nil {
+			log.Printf("output sink write failed: %v", err)
+		}
+	}
+}
+
+// closeSinks closes every configured output sink, continuing past a failure
+// so one broken sink doesn't leak every other sink's open file/db handle.
+func (fjs *FresherJobScraper) closeSinks() error {
+	fjs.sinksMutex.Lock()
+	defer fjs.sinksMutex.Unlock()
+
+	var firstErr error
+	for _, sink := range fjs.sinks {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// GetStats returns scraping statistics
+func (fjs *FresherJobScraper) GetStats() {
+	fjs.jobsMutex.Lock()
+	defer fjs.jobsMutex.Unlock()
+
+	stats := make(map[string]int)
+	for _, job := range fjs.jobs {
+		stats[job.Platform]++
+	}
+
+	log.Println("=== Scraping Statistics ===")
+	log.Printf("Total Jobs: %d", len(fjs.jobs))
+	for platform, count := range stats {
+		log.Printf("%s: %d jobs", platform, count)
+	}
+	log.Println("===========================")
+}
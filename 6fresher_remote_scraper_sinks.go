@@ -0,0 +1,250 @@
+package main
+
+// Shared by both the CLI entrypoint (6fresher_remote_scraper.go, built
+// without -tags lambda) and the Lambda entrypoint
+// (6fresher_remote_scraper_lambda.go, built with -tags lambda): the
+// OutputSink interface and its implementations have no build-specific
+// logic, so they carry no build tag and stay visible to whichever
+// entrypoint is compiled in.
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// OutputSink is implemented by every format a scrape run can be persisted
+// to. Write is called once per job as it's discovered (see
+// FresherJobScraper.writeJobToSinks), so a streaming sink (JSONL, SQLite,
+// webhook) never has to buffer the full result set the way a JSON array
+// does.
+type OutputSink interface {
+	Write(job FresherJob) error
+	Close() error
+}
+
+var fresherJobHeaders = []string{
+	"Platform", "Title", "Company", "Location", "Description",
+	"Salary", "PostedDate", "IsRemote", "IsFresher", "URL", "ApplyURL", "Skills",
+}
+
+func fresherJobRecord(job FresherJob) []string {
+	return []string{
+		job.Platform,
+		job.Title,
+		job.Company,
+		job.Location,
+		job.Description,
+		job.Salary,
+		job.PostedDate,
+		fmt.Sprintf("%t", job.IsRemote),
+		fmt.Sprintf("%t", job.IsFresher),
+		job.URL,
+		job.ApplyURL,
+		strings.Join(job.Skills.Matched, ";"),
+	}
+}
+
+// CSVSink writes one row per job, flushing after every write so a crash
+// mid-run loses at most the in-flight record.
+type CSVSink struct {
+	file   *os.File
+	writer *csv.Writer
+}
+
+func NewCSVSink(filename string) (*CSVSink, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("creating CSV file: %w", err)
+	}
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(fresherJobHeaders); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("writing CSV headers: %w", err)
+	}
+
+	return &CSVSink{file: file, writer: writer}, nil
+}
+
+func (s *CSVSink) Write(job FresherJob) error {
+	if err := s.writer.Write(fresherJobRecord(job)); err != nil {
+		return fmt.Errorf("writing CSV record: %w", err)
+	}
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+func (s *CSVSink) Close() error {
+	s.writer.Flush()
+	return s.file.Close()
+}
+
+// JSONSink buffers every job and writes a single indented JSON array on
+// Close, so the full structured record - including the nested Skills
+// breakdown CSV can't represent - survives to disk.
+type JSONSink struct {
+	filename string
+	jobs     []FresherJob
+}
+
+func NewJSONSink(filename string) *JSONSink {
+	return &JSONSink{filename: filename}
+}
+
+func (s *JSONSink) Write(job FresherJob) error {
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+func (s *JSONSink) Close() error {
+	data, err := json.MarshalIndent(s.jobs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling jobs: %w", err)
+	}
+	return os.WriteFile(s.filename, data, 0o644)
+}
+
+// JSONLSink writes one job per line as it's scraped, so memory stays flat
+// and a crash mid-run doesn't lose everything collected so far the way
+// JSONSink's buffered array would.
+type JSONLSink struct {
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func NewJSONLSink(filename string) (*JSONLSink, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("creating JSONL file: %w", err)
+	}
+	return &JSONLSink{file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+func (s *JSONLSink) Write(job FresherJob) error {
+	return s.encoder.Encode(job)
+}
+
+func (s *JSONLSink) Close() error {
+	return s.file.Close()
+}
+
+// SQLiteSink persists jobs to a SQLite database via modernc.org/sqlite (a
+// pure-Go driver, so the scraper stays CGO-free), upserting on
+// (platform, url) so a repeat run against the same DSN updates existing
+// rows instead of duplicating them.
+type SQLiteSink struct {
+	db *sql.DB
+}
+
+func NewSQLiteSink(dsn string) (*SQLiteSink, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite db: %w", err)
+	}
+
+	const schema = `CREATE TABLE IF NOT EXISTS jobs (
+		platform    TEXT,
+		title       TEXT,
+		company     TEXT,
+		location    TEXT,
+		description TEXT,
+		salary      TEXT,
+		posted_date TEXT,
+		is_remote   INTEGER,
+		is_fresher  INTEGER,
+		url         TEXT,
+		apply_url   TEXT,
+		skills      TEXT,
+		scraped_at  TEXT,
+		UNIQUE(platform, url)
+	)`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating jobs table: %w", err)
+	}
+
+	return &SQLiteSink{db: db}, nil
+}
+
+func (s *SQLiteSink) Write(job FresherJob) error {
+	_, err := s.db.Exec(
+		`INSERT INTO jobs (platform, title, company, location, description, salary, posted_date, is_remote, is_fresher, url, apply_url, skills, scraped_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(platform, url) DO UPDATE SET
+			title=excluded.title, company=excluded.company, location=excluded.location,
+			description=excluded.description, salary=excluded.salary, posted_date=excluded.posted_date,
+			is_remote=excluded.is_remote, is_fresher=excluded.is_fresher, apply_url=excluded.apply_url,
+			skills=excluded.skills, scraped_at=excluded.scraped_at`,
+		job.Platform, job.Title, job.Company, job.Location, job.Description, job.Salary, job.PostedDate,
+		job.IsRemote, job.IsFresher, job.URL, job.ApplyURL, strings.Join(job.Skills.Matched, ";"),
+		job.ScrapedAt.Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("upserting job %s: %w", job.URL, err)
+	}
+	return nil
+}
+
+func (s *SQLiteSink) Close() error {
+	return s.db.Close()
+}
+
+const (
+	webhookMaxRetries  = 4
+	webhookBaseBackoff = 500 * time.Millisecond
+)
+
+// WebhookSink POSTs each job as a JSON body to a user-supplied URL, retrying
+// a network error, a 429, or a 5xx with exponential backoff so one slow or
+// briefly-down endpoint doesn't drop jobs from a long run.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *WebhookSink) Write(job FresherJob) error {
+	body, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("encoding job for webhook: %w", err)
+	}
+
+	var lastErr error
+	backoff := webhookBaseBackoff
+	for attempt := 0; attempt <= webhookMaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 300 {
+			return nil
+		}
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			return fmt.Errorf("webhook returned non-retryable status %d for job %s", resp.StatusCode, job.URL)
+		}
+		lastErr = fmt.Errorf("webhook returned status %d for job %s", resp.StatusCode, job.URL)
+	}
+	return fmt.Errorf("webhook delivery failed after %d attempts: %w", webhookMaxRetries+1, lastErr)
+}
+
+func (s *WebhookSink) Close() error { return nil }
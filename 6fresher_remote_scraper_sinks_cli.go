@@ -0,0 +1,63 @@
+//go:build !lambda
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stringSliceFlag collects every occurrence of a repeated flag (e.g.
+// -output csv -output sqlite) into a slice, instead of one comma-separated
+// value.
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// buildOutputSinks turns the sink names named by repeated -output flags,
+// plus an optional webhookURL, into the OutputSinks a scrape run should
+// write through as jobs are discovered.
+func buildOutputSinks(outputs []string, webhookURL, timestamp string) ([]OutputSink, error) {
+	var sinks []OutputSink
+	for _, name := range outputs {
+		switch strings.TrimSpace(strings.ToLower(name)) {
+		case "":
+			// tolerate a stray empty value
+		case "csv":
+			sink, err := NewCSVSink(fmt.Sprintf("remote_fresher_jobs_%s.csv", timestamp))
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case "json":
+			sinks = append(sinks, NewJSONSink(fmt.Sprintf("remote_fresher_jobs_%s.json", timestamp)))
+		case "jsonl":
+			sink, err := NewJSONLSink(fmt.Sprintf("remote_fresher_jobs_%s.jsonl", timestamp))
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case "sqlite":
+			sink, err := NewSQLiteSink(fmt.Sprintf("remote_fresher_jobs_%s.db", timestamp))
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case "webhook":
+			if webhookURL == "" {
+				return nil, fmt.Errorf("-output webhook requires -webhook-url")
+			}
+			sinks = append(sinks, NewWebhookSink(webhookURL))
+		default:
+			return nil, fmt.Errorf("unknown -output sink %q", name)
+		}
+	}
+	return sinks, nil
+}
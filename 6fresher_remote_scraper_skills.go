@@ -0,0 +1,172 @@
+package main
+
+// Shared by both the CLI entrypoint (6fresher_remote_scraper.go, built
+// without -tags lambda) and the Lambda entrypoint
+// (6fresher_remote_scraper_lambda.go, built with -tags lambda): skill
+// extraction has no build-specific logic, so it carries no build tag and
+// stays visible to whichever entrypoint is compiled in.
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FresherJobSkills records which well-known technologies a job's title and
+// description mention: one bool per common skill, plus Matched, which holds
+// every matched skill name - including ones from a user-supplied skills.yaml
+// with no dedicated field above. CSV and SQLite output store only Matched,
+// joined by ";", in a single skills column; the JSON output carries the
+// whole struct.
+type FresherJobSkills struct {
+	React      bool
+	Go         bool
+	Python     bool
+	TypeScript bool
+	Tailwind   bool
+	NextJS     bool
+	Kubernetes bool
+	Docker     bool
+	AWS        bool
+	Matched    []string
+}
+
+// SkillTerm maps one technology name to the case-insensitive, word-boundary
+// regexes that indicate a job mentions it.
+type SkillTerm struct {
+	Name     string   `yaml:"name"`
+	Patterns []string `yaml:"patterns"`
+}
+
+// defaultSkillDictionary is used whenever no skills.yaml is supplied. Every
+// pattern is word-boundary-anchored so e.g. "go" doesn't match "Google".
+func defaultSkillDictionary() []SkillTerm {
+	return []SkillTerm{
+		{Name: "react", Patterns: []string{`\breact(\.js)?\b`}},
+		{Name: "go", Patterns: []string{`\bgo(lang)?\b`}},
+		{Name: "python", Patterns: []string{`\bpython\b`}},
+		{Name: "typescript", Patterns: []string{`\btypescript\b`, `\bts\b`}},
+		{Name: "tailwind", Patterns: []string{`\btailwind(css)?\b`}},
+		{Name: "nextjs", Patterns: []string{`\bnext\.js\b`, `\bnextjs\b`}},
+		{Name: "kubernetes", Patterns: []string{`\bkubernetes\b`, `\bk8s\b`}},
+		{Name: "docker", Patterns: []string{`\bdocker\b`}},
+		{Name: "aws", Patterns: []string{`\baws\b`, `\bamazon web services\b`}},
+	}
+}
+
+// LoadSkillDictionary reads a skills.yaml file, e.g.:
+//
+//	- name: rust
+//	  patterns: ["\\brust\\b"]
+func LoadSkillDictionary(path string) ([]SkillTerm, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading skills dictionary: %w", err)
+	}
+	var terms []SkillTerm
+	if err := yaml.Unmarshal(data, &terms); err != nil {
+		return nil, fmt.Errorf("parsing skills dictionary %s: %w", path, err)
+	}
+	return terms, nil
+}
+
+// compiledSkillTerm is a SkillTerm with its patterns pre-compiled.
+type compiledSkillTerm struct {
+	name     string
+	patterns []*regexp.Regexp
+}
+
+// SkillExtractor tags FresherJobs with the skills their title+description
+// mention, driven by a dictionary so the taxonomy can grow without
+// recompiling.
+type SkillExtractor struct {
+	terms []compiledSkillTerm
+}
+
+// NewSkillExtractor compiles the dictionary at path (YAML, see
+// LoadSkillDictionary), or defaultSkillDictionary if path is empty.
+func NewSkillExtractor(path string) (*SkillExtractor, error) {
+	terms := defaultSkillDictionary()
+	if path != "" {
+		loaded, err := LoadSkillDictionary(path)
+		if err != nil {
+			return nil, err
+		}
+		terms = loaded
+	}
+
+	se := &SkillExtractor{}
+	for _, term := range terms {
+		compiled := compiledSkillTerm{name: strings.ToLower(term.Name)}
+		for _, pattern := range term.Patterns {
+			re, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				return nil, fmt.Errorf("skill %q: invalid pattern %q: %w", term.Name, pattern, err)
+			}
+			compiled.patterns = append(compiled.patterns, re)
+		}
+		se.terms = append(se.terms, compiled)
+	}
+	return se, nil
+}
+
+// skillFieldSetters maps a skill name to the FresherJobSkills field it sets.
+var skillFieldSetters = map[string]func(*FresherJobSkills){
+	"react":      func(s *FresherJobSkills) { s.React = true },
+	"go":         func(s *FresherJobSkills) { s.Go = true },
+	"python":     func(s *FresherJobSkills) { s.Python = true },
+	"typescript": func(s *FresherJobSkills) { s.TypeScript = true },
+	"tailwind":   func(s *FresherJobSkills) { s.Tailwind = true },
+	"nextjs":     func(s *FresherJobSkills) { s.NextJS = true },
+	"kubernetes": func(s *FresherJobSkills) { s.Kubernetes = true },
+	"docker":     func(s *FresherJobSkills) { s.Docker = true },
+	"aws":        func(s *FresherJobSkills) { s.AWS = true },
+}
+
+// Extract scans job's title and description, sets the matching booleans and
+// Matched on job.Skills, and returns the matched skill names.
+func (se *SkillExtractor) Extract(job *FresherJob) []string {
+	haystack := job.Title + " " + job.Description
+
+	var matched []string
+	for _, term := range se.terms {
+		for _, pattern := range term.patterns {
+			if !pattern.MatchString(haystack) {
+				continue
+			}
+			matched = append(matched, term.name)
+			if setField, ok := skillFieldSetters[term.name]; ok {
+				setField(&job.Skills)
+			}
+			break
+		}
+	}
+	job.Skills.Matched = matched
+	return matched
+}
+
+// MatchesSkillFilter reports whether job's matched skills satisfy required
+// (every entry must be present) and excluded (no entry may be present),
+// case-insensitively. A nil/empty required or excluded imposes no
+// constraint.
+func (job FresherJob) MatchesSkillFilter(required, excluded []string) bool {
+	has := make(map[string]bool, len(job.Skills.Matched))
+	for _, s := range job.Skills.Matched {
+		has[strings.ToLower(s)] = true
+	}
+
+	for _, r := range required {
+		if !has[strings.ToLower(strings.TrimSpace(r))] {
+			return false
+		}
+	}
+	for _, e := range excluded {
+		if has[strings.ToLower(strings.TrimSpace(e))] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package main
+
+// Shared by both the CLI entrypoint (6fresher_remote_scraper.go, built
+// without -tags lambda) and the Lambda entrypoint
+// (6fresher_remote_scraper_lambda.go, built with -tags lambda): the
+// per-domain throttle has no build-specific logic, so it carries no build
+// tag and stays visible to whichever entrypoint is compiled in.
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	throttleBaseDelay   = 3 * time.Second
+	throttleFloor       = 1 * time.Second
+	throttleCap         = 60 * time.Second
+	throttleHalveStreak = 5 // consecutive 200s before halving the delay back down
+)
+
+// domainThrottleEntry tracks one domain's current adaptive delay and its
+// streak of consecutive 200 responses.
+type domainThrottleEntry struct {
+	mu            sync.Mutex
+	delay         time.Duration
+	successStreak int
+}
+
+// DomainThrottle replaces the single fixed rate.NewLimiter every collector
+// used to share: it keeps one adaptive delay per domain, doubling it on a
+// 429/403 (up to throttleCap) and halving it back down after
+// throttleHalveStreak consecutive 200s (down to throttleFloor), so a long
+// run self-tunes instead of needing the delay hand-picked per site.
+type DomainThrottle struct {
+	mu      sync.Mutex
+	domains map[string]*domainThrottleEntry
+}
+
+// NewDomainThrottle returns a DomainThrottle with every domain starting at
+// throttleBaseDelay.
+func NewDomainThrottle() *DomainThrottle {
+	return &DomainThrottle{domains: make(map[string]*domainThrottleEntry)}
+}
+
+func (dt *DomainThrottle) entryFor(domain string) *domainThrottleEntry {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	entry, ok := dt.domains[domain]
+	if !ok {
+		entry = &domainThrottleEntry{delay: throttleBaseDelay}
+		dt.domains[domain] = entry
+	}
+	return entry
+}
+
+// Wait blocks for domain's current adaptive delay before a caller is allowed
+// to send its next request.
+func (dt *DomainThrottle) Wait(domain string) {
+	entry := dt.entryFor(domain)
+
+	entry.mu.Lock()
+	delay := entry.delay
+	entry.mu.Unlock()
+
+	time.Sleep(delay)
+}
+
+// ReportStatus adjusts domain's delay based on statusCode: a 429 or 403
+// doubles it (capped at throttleCap) and resets the success streak; a 200
+// extends the streak, halving the delay (floored at throttleFloor) once the
+// streak reaches throttleHalveStreak. Any other status is ignored.
+func (dt *DomainThrottle) ReportStatus(domain string, statusCode int) {
+	entry := dt.entryFor(domain)
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusForbidden:
+		entry.successStreak = 0
+		entry.delay *= 2
+		if entry.delay > throttleCap {
+			entry.delay = throttleCap
+		}
+	case http.StatusOK:
+		entry.successStreak++
+		if entry.successStreak >= throttleHalveStreak {
+			entry.successStreak = 0
+			entry.delay /= 2
+			if entry.delay < throttleFloor {
+				entry.delay = throttleFloor
+			}
+		}
+	}
+}
+
+// domainOf extracts rawURL's host, used as the throttle/proxy-health
+// tracking key since a site's different paths all share the same domain.
+func domainOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Host
+}
@@ -0,0 +1,86 @@
+//go:build lambda
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// remoteLambdaRequest is the event payload accepted by the Lambda handler,
+// decoded directly from the invocation's JSON event; an API Gateway proxy
+// request envelope is not unwrapped into it.
+type remoteLambdaRequest struct {
+	Query        string `json:"query"`
+	Skills       string `json:"skills"`
+	MaxDepth     int    `json:"maxDepth"`
+	Concurrency  int    `json:"concurrency"`
+	SameHostOnly bool   `json:"sameHostOnly"`
+}
+
+// remoteLambdaCacheEntry is a single warm-container cache hit.
+type remoteLambdaCacheEntry struct {
+	jobs      []RemoteJob
+	scrapedAt time.Time
+}
+
+// remoteLambdaCacheTTL bounds how long a warm container reuses a previous
+// scrape for the same request instead of hitting the job boards again.
+const remoteLambdaCacheTTL = 5 * time.Minute
+
+var (
+	remoteLambdaCacheMu sync.Mutex
+	remoteLambdaCache   = map[string]remoteLambdaCacheEntry{}
+)
+
+func remoteLambdaCacheKey(req remoteLambdaRequest) string {
+	return req.Query + "|" + req.Skills
+}
+
+// handleRemoteLambdaRequest runs a scrape across every registered
+// SourceAdapter and returns the merged, enriched jobs, serving a warm cache
+// hit when one is fresh enough instead of re-scraping.
+func handleRemoteLambdaRequest(ctx context.Context, req remoteLambdaRequest) ([]RemoteJob, error) {
+	key := remoteLambdaCacheKey(req)
+
+	remoteLambdaCacheMu.Lock()
+	if entry, ok := remoteLambdaCache[key]; ok && time.Since(entry.scrapedAt) < remoteLambdaCacheTTL {
+		remoteLambdaCacheMu.Unlock()
+		return entry.jobs, nil
+	}
+	remoteLambdaCacheMu.Unlock()
+
+	maxDepth := req.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = 2
+	}
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = 2
+	}
+	sharedDetailCrawler = newDetailCrawler(maxDepth, concurrency, req.SameHostOnly)
+
+	scraper := NewJobScraper()
+	scraper.CacheDir = "" // a Lambda invocation has no durable disk across cold starts
+	scraper.skillsFilter = parseSkillsFilter(req.Skills)
+
+	if err := scraper.ScrapeAllSources(ctx, req.Query); err != nil {
+		return nil, err
+	}
+
+	remoteLambdaCacheMu.Lock()
+	remoteLambdaCache[key] = remoteLambdaCacheEntry{jobs: scraper.jobs, scrapedAt: time.Now()}
+	remoteLambdaCacheMu.Unlock()
+
+	return scraper.jobs, nil
+}
+
+// main is the Lambda entrypoint. Build with build.sh, or directly via:
+//
+//	GOOS=linux GOARCH=arm64 go build -tags lambda.norpc,lambda -o bootstrap .
+func main() {
+	lambda.Start(handleRemoteLambdaRequest)
+}
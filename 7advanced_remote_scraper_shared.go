@@ -0,0 +1,1625 @@
+package main
+
+// Shared by both the CLI entrypoint (7advanced_remote_scraper.go, built
+// without -tags lambda) and the Lambda entrypoint
+// (7advanced_remote_scraper_lambda.go, built with -tags lambda): none of
+// this has build-specific logic, so it carries no build tag and stays
+// visible to whichever entrypoint is compiled in.
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly/v2"
+	_ "github.com/mattn/go-sqlite3"
+	"golang.org/x/time/rate"
+)
+
+// JobSkills records which well-known skills a job's title/description
+// mention, one bool per skill so CSV output can emit a fixed set of columns.
+type JobSkills struct {
+	React      bool
+	Python     bool
+	Golang     bool
+	TypeScript bool
+	NextJS     bool
+	Tailwind   bool
+	Docker     bool
+	Kubernetes bool
+	AWS        bool
+}
+
+// RemoteJob represents a remote job listing
+type RemoteJob struct {
+	ID          string
+	Platform    string
+	Title       string
+	Company     string
+	Location    string
+	Description string
+	Salary      string
+	PostedDate  string
+	JobType     string
+	Experience  string
+	Tags        []string
+	Skills      JobSkills
+	IsRemote    bool
+	IsFresher   bool
+	URL         string
+	ApplyURL    string
+	// FirstSeen is when this run's addJob call first recorded the job, used
+	// by the --since flag to report only newly discovered postings.
+	FirstSeen time.Time
+}
+
+var userAgents = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:122.0) Gecko/20100101 Firefox/122.0",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:122.0) Gecko/20100101 Firefox/122.0",
+}
+
+var fresherKeywords = []string{
+	"entry level", "junior", "fresher", "graduate", "trainee", "intern", "associate",
+	"no experience", "0-1 years", "0-2 years", "recent graduate", "new grad",
+	"entry-level", "beginner", "starting", "career starter", "apprentice",
+}
+
+var remoteKeywords = []string{
+	"remote", "work from home", "telecommute", "distributed", "virtual",
+	"home office", "anywhere", "location independent", "wfh", "remote-first",
+	"fully remote", "100% remote", "remote work", "remote position",
+}
+
+var excludeKeywords = []string{
+	"senior", "lead", "principal", "architect", "manager", "director", "head of",
+	"5+ years", "10+ years", "experienced", "expert", "specialist", "chief",
+	"3+ years", "4+ years", "minimum 3", "minimum 5", "at least 3", "at least 5",
+}
+
+var jobTitles = []string{
+	// Software Development
+	"software engineer", "software developer", "web developer", "frontend developer",
+	"backend developer", "full stack developer", "junior developer", "entry level developer",
+	"python developer", "javascript developer", "react developer", "node.js developer",
+	"java developer", "c# developer", "php developer", "ruby developer",
+	"mobile developer", "ios developer", "android developer", "flutter developer",
+
+	// Data & Analytics
+	"data analyst", "data scientist", "business analyst", "research analyst",
+	"sql analyst", "reporting analyst", "junior data analyst",
+
+	// Quality Assurance
+	"qa engineer", "software tester", "test engineer", "quality assurance",
+	"automation tester", "manual tester",
+
+	// DevOps & Infrastructure
+	"devops engineer", "cloud engineer", "system administrator", "infrastructure engineer",
+
+	// Design & UX
+	"ui designer", "ux designer", "ui/ux designer", "graphic designer",
+	"web designer", "product designer", "visual designer",
+
+	// Marketing & Content
+	"digital marketing", "content writer", "marketing coordinator", "social media",
+	"seo specialist", "content creator", "marketing assistant",
+
+	// Customer Support
+	"customer support", "technical support", "help desk", "customer success",
+
+	// Project Management
+	"product manager", "project coordinator", "scrum master", "business analyst",
+
+	// Sales
+	"sales representative", "account executive", "business development", "inside sales",
+}
+
+// randomUserAgent returns a random user agent
+func randomUserAgent() string {
+	return userAgents[rand.Intn(len(userAgents))]
+}
+
+// scraperCacheDir points every adapter's collector at the same on-disk HTTP
+// response cache. Adapters are deliberately stateless (see SourceAdapter),
+// so JobScraper.ScrapeAllSources sets this package var before dispatching
+// them rather than threading CacheDir through the interface.
+var scraperCacheDir string
+
+// newScraperCollector creates a well-configured collector shared by every
+// SourceAdapter, with per-domain rate limiting, realistic headers, and
+// (when scraperCacheDir is set) an on-disk response cache so repeat runs
+// skip re-fetching pages they already have.
+func newScraperCollector() *colly.Collector {
+	c := colly.NewCollector()
+	c.UserAgent = randomUserAgent()
+
+	if scraperCacheDir != "" {
+		c.CacheDir = filepath.Join(scraperCacheDir, "pages")
+	}
+
+	// Set realistic headers
+	c.OnRequest(func(r *colly.Request) {
+		r.Headers.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8")
+		r.Headers.Set("Accept-Language", "en-US,en;q=0.9")
+		r.Headers.Set("Accept-Encoding", "gzip, deflate, br")
+		r.Headers.Set("Connection", "keep-alive")
+		r.Headers.Set("Upgrade-Insecure-Requests", "1")
+		r.Headers.Set("Sec-Fetch-Dest", "document")
+		r.Headers.Set("Sec-Fetch-Mode", "navigate")
+		r.Headers.Set("Sec-Fetch-Site", "same-origin")
+		r.Headers.Set("Cache-Control", "max-age=0")
+		r.Headers.Set("DNT", "1")
+	})
+
+	c.Limit(&colly.LimitRule{
+		DomainGlob:  "*",
+		Parallelism: 1,
+		Delay:       2 * time.Second,
+	})
+
+	c.SetRequestTimeout(30 * time.Second)
+	return c
+}
+
+// isFresherJob checks if a job is suitable for freshers
+func isFresherJob(title, description string) bool {
+	combined := strings.ToLower(title + " " + description)
+
+	// Check exclusions first
+	for _, keyword := range excludeKeywords {
+		if strings.Contains(combined, keyword) {
+			return false
+		}
+	}
+
+	// Check for fresher keywords
+	for _, keyword := range fresherKeywords {
+		if strings.Contains(combined, keyword) {
+			return true
+		}
+	}
+
+	// Check patterns
+	patterns := []string{
+		`0[\s-]?[12]?\s*years?`,
+		`entry[\s-]?level`,
+		`new[\s-]?grad`,
+		`no[\s-]?experience`,
+		`recent[\s-]?graduate`,
+	}
+
+	for _, pattern := range patterns {
+		matched, _ := regexp.MatchString(pattern, combined)
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRemoteJob checks if a job is remote
+func isRemoteJob(title, location, description string) bool {
+	combined := strings.ToLower(title + " " + location + " " + description)
+
+	for _, keyword := range remoteKeywords {
+		if strings.Contains(combined, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SkillRule maps a skill name to the case-insensitive regex patterns
+// (including synonyms) that indicate a job mentions it.
+type SkillRule struct {
+	Name     string   `json:"name"`
+	Patterns []string `json:"patterns"`
+}
+
+// defaultSkillRules is used whenever no --skills-rules file is supplied.
+func defaultSkillRules() []SkillRule {
+	return []SkillRule{
+		{Name: "react", Patterns: []string{`react(\.js)?`}},
+		{Name: "python", Patterns: []string{`python`}},
+		{Name: "golang", Patterns: []string{`golang`, `\bgo\b`, `go developer`}},
+		{Name: "typescript", Patterns: []string{`typescript`, `\bts\b`}},
+		{Name: "nextjs", Patterns: []string{`next\.js`, `nextjs`}},
+		{Name: "tailwind", Patterns: []string{`tailwind`}},
+		{Name: "docker", Patterns: []string{`docker`}},
+		{Name: "kubernetes", Patterns: []string{`kubernetes`, `\bk8s\b`}},
+		{Name: "aws", Patterns: []string{`\baws\b`, `amazon web services`}},
+	}
+}
+
+// loadSkillRules reads a JSON rules file mapping skill names to regex
+// patterns/synonyms, e.g.:
+//
+//	[{"name": "golang", "patterns": ["golang", "\\bgo\\b", "go developer"]}]
+func loadSkillRules(path string) ([]SkillRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading skill rules: %w", err)
+	}
+	var rules []SkillRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parsing skill rules: %w", err)
+	}
+	return rules, nil
+}
+
+// compiledSkillRule is a SkillRule with its patterns pre-compiled.
+type compiledSkillRule struct {
+	name     string
+	patterns []*regexp.Regexp
+}
+
+// SkillExtractor tags jobs with the skills their title+description mention,
+// driven by a set of rules so the taxonomy can be extended without
+// recompiling.
+type SkillExtractor struct {
+	rules []compiledSkillRule
+}
+
+// NewSkillExtractor compiles the rules at path (JSON, see loadSkillRules),
+// or defaultSkillRules if path is empty.
+func NewSkillExtractor(path string) (*SkillExtractor, error) {
+	rules := defaultSkillRules()
+	if path != "" {
+		loaded, err := loadSkillRules(path)
+		if err != nil {
+			return nil, err
+		}
+		rules = loaded
+	}
+
+	se := &SkillExtractor{}
+	for _, rule := range rules {
+		compiled := compiledSkillRule{name: strings.ToLower(rule.Name)}
+		for _, pattern := range rule.Patterns {
+			re, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				return nil, fmt.Errorf("skill %q: invalid pattern %q: %w", rule.Name, pattern, err)
+			}
+			compiled.patterns = append(compiled.patterns, re)
+		}
+		se.rules = append(se.rules, compiled)
+	}
+	return se, nil
+}
+
+// skillFieldSetters maps a skill name to the JobSkills field it sets.
+var skillFieldSetters = map[string]func(*JobSkills){
+	"react":      func(s *JobSkills) { s.React = true },
+	"python":     func(s *JobSkills) { s.Python = true },
+	"golang":     func(s *JobSkills) { s.Golang = true },
+	"typescript": func(s *JobSkills) { s.TypeScript = true },
+	"nextjs":     func(s *JobSkills) { s.NextJS = true },
+	"tailwind":   func(s *JobSkills) { s.Tailwind = true },
+	"docker":     func(s *JobSkills) { s.Docker = true },
+	"kubernetes": func(s *JobSkills) { s.Kubernetes = true },
+	"aws":        func(s *JobSkills) { s.AWS = true },
+}
+
+// Extract scans job's title and description, sets the matching booleans on
+// job.Skills, and returns the lowercase skill names that matched (used by
+// the --skills filter flag).
+func (se *SkillExtractor) Extract(job *RemoteJob) []string {
+	haystack := strings.ToLower(job.Title + " " + job.Description)
+
+	var matched []string
+	for _, rule := range se.rules {
+		for _, pattern := range rule.patterns {
+			if !pattern.MatchString(haystack) {
+				continue
+			}
+			matched = append(matched, rule.name)
+			if setField, ok := skillFieldSetters[rule.name]; ok {
+				setField(&job.Skills)
+			}
+			break
+		}
+	}
+	return matched
+}
+
+// skillAliases maps shorthand --skills values to their canonical rule name.
+var skillAliases = map[string]string{
+	"go":   "golang",
+	"ts":   "typescript",
+	"next": "nextjs",
+	"k8s":  "kubernetes",
+}
+
+// parseSkillsFilter turns a comma-separated --skills flag value into a set
+// of canonical skill names, or nil if raw is empty (meaning: no filtering).
+func parseSkillsFilter(raw string) map[string]bool {
+	if raw == "" {
+		return nil
+	}
+	filter := make(map[string]bool)
+	for _, s := range strings.Split(raw, ",") {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "" {
+			continue
+		}
+		if alias, ok := skillAliases[s]; ok {
+			s = alias
+		}
+		filter[s] = true
+	}
+	return filter
+}
+
+// matchesSkillsFilter reports whether any of matched is present in filter.
+// An empty or nil filter matches everything.
+func matchesSkillsFilter(matched []string, filter map[string]bool) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, m := range matched {
+		if filter[m] {
+			return true
+		}
+	}
+	return false
+}
+
+// salaryPattern matches a dollar figure (optionally a range) in raw page
+// text, e.g. "$45,000 - $65,000" or "$25/hour".
+var salaryPattern = regexp.MustCompile(`\$[\d,]+(?:\.\d+)?(?:\s*-\s*\$?[\d,]+(?:\.\d+)?)?(?:\s*/\s*(?:year|yr|hour|hr))?`)
+
+// extractSalary returns the first dollar-figure-looking substring of text, or "".
+func extractSalary(text string) string {
+	return salaryPattern.FindString(text)
+}
+
+// extractSnippet collapses whitespace in text and truncates it to a
+// plausible job-description length.
+func extractSnippet(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	const maxLen = 500
+	if len(text) > maxLen {
+		return text[:maxLen]
+	}
+	return text
+}
+
+// detailCrawlerEntry is a single frontier item: a detail-page URL paired
+// with the depth it was discovered at.
+type detailCrawlerEntry struct {
+	u     *url.URL
+	depth int
+}
+
+// detailCrawler recursively follows links starting from a job's detail URL
+// to pull in fields the listing page didn't carry. MaxDepth counts pages,
+// the detail URL itself being depth 1, so links are followed at most
+// MaxDepth-1 hops. It dedupes visits with a sync.Map and caps in-flight
+// requests per host with a semaphore; each collector Enrich builds has
+// robots.txt checking turned on, so it skips paths a site has disallowed.
+type detailCrawler struct {
+	MaxDepth     int
+	Concurrency  int
+	SameHostOnly bool
+
+	visited sync.Map
+
+	hostSemMu sync.Mutex
+	hostSem   map[string]chan struct{}
+}
+
+// newDetailCrawler builds a detailCrawler with the given bounds.
+func newDetailCrawler(maxDepth, concurrency int, sameHostOnly bool) *detailCrawler {
+	return &detailCrawler{
+		MaxDepth:     maxDepth,
+		Concurrency:  concurrency,
+		SameHostOnly: sameHostOnly,
+		hostSem:      make(map[string]chan struct{}),
+	}
+}
+
+// acquire blocks until a slot is free for host, bounding in-flight requests
+// per host to Concurrency regardless of how many jobs are being enriched at once.
+func (dc *detailCrawler) acquire(host string) {
+	dc.hostSemMu.Lock()
+	sem, ok := dc.hostSem[host]
+	if !ok {
+		sem = make(chan struct{}, dc.Concurrency)
+		dc.hostSem[host] = sem
+	}
+	dc.hostSemMu.Unlock()
+	sem <- struct{}{}
+}
+
+func (dc *detailCrawler) release(host string) {
+	dc.hostSemMu.Lock()
+	sem := dc.hostSem[host]
+	dc.hostSemMu.Unlock()
+	<-sem
+}
+
+// Enrich fetches job.URL and, up to MaxDepth, links discovered from it,
+// filling in Description and Salary from the first page text that has them
+// if the listing page left those fields empty.
+func (dc *detailCrawler) Enrich(ctx context.Context, job *RemoteJob) error {
+	start, err := url.Parse(job.URL)
+	if err != nil || start.Host == "" {
+		return nil // nothing usable to crawl
+	}
+
+	var pending sync.WaitGroup
+	var fieldsMu sync.Mutex
+	frontier := make(chan detailCrawlerEntry, 16)
+
+	pending.Add(1)
+	frontier <- detailCrawlerEntry{u: start, depth: 1}
+
+	go func() {
+		pending.Wait()
+		close(frontier)
+	}()
+
+	for entry := range frontier {
+		if _, seen := dc.visited.LoadOrStore(entry.u.String(), true); seen {
+			pending.Done()
+			continue
+		}
+
+		dc.acquire(entry.u.Host)
+		c := newScraperCollector()
+		c.IgnoreRobotsTxt = false // colly ignores robots.txt unless told otherwise
+
+		c.OnResponse(func(r *colly.Response) {
+			text := string(r.Body)
+			fieldsMu.Lock()
+			if job.Description == "" {
+				job.Description = extractSnippet(text)
+			}
+			if job.Salary == "" {
+				job.Salary = extractSalary(text)
+			}
+			fieldsMu.Unlock()
+		})
+
+		if entry.depth < dc.MaxDepth {
+			c.OnHTML("a[href]", func(e *colly.HTMLElement) {
+				next, err := url.Parse(e.Request.AbsoluteURL(e.Attr("href")))
+				if err != nil {
+					return
+				}
+				if dc.SameHostOnly && next.Host != start.Host {
+					return
+				}
+				pending.Add(1)
+				select {
+				case frontier <- detailCrawlerEntry{u: next, depth: entry.depth + 1}:
+				default:
+					pending.Done() // frontier buffer is full; drop rather than block
+				}
+			})
+		}
+
+		c.OnError(func(r *colly.Response, err error) {
+			log.Printf("[detail] %s: %v", r.Request.URL, err)
+		})
+
+		_ = c.Visit(entry.u.String())
+		dc.release(entry.u.Host)
+		pending.Done()
+	}
+
+	return nil
+}
+
+// sharedDetailCrawler enriches every adapter's jobs; main() rebuilds it from
+// the --max-depth, --concurrency, and --same-host-only flags.
+var sharedDetailCrawler = newDetailCrawler(2, 2, true)
+
+// FieldRule extracts one RemoteJob field from a listing element: Selector
+// narrows to a descendant (empty means the listing element itself), Attr
+// names the attribute to read (empty means text content), and an optional
+// Regex narrows or reshapes the raw value.
+type FieldRule struct {
+	Selector string `json:"selector"`
+	Attr     string `json:"attr,omitempty"`
+	Regex    string `json:"regex,omitempty"`
+}
+
+// SiteRules is a source's entire scraping rule set: where to start and how
+// to pull each field out of a listing element. Shipping a new board, or
+// adapting to an existing one's HTML change, is a rules/ JSON file edit
+// instead of a code change.
+type SiteRules struct {
+	Name            string               `json:"name"`
+	StartURL        string               `json:"start_url"`
+	ListingSelector string               `json:"listing_selector"`
+	Fields          map[string]FieldRule `json:"fields"`
+}
+
+// LoadSiteRules reads a single JSON rules file.
+func LoadSiteRules(path string) (SiteRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SiteRules{}, fmt.Errorf("reading rules file: %w", err)
+	}
+	var rules SiteRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return SiteRules{}, fmt.Errorf("parsing rules file %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// LoadRulesDir loads every *.json file in dir into a SiteRules keyed by its Name field.
+func LoadRulesDir(dir string) (map[string]SiteRules, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading rules dir: %w", err)
+	}
+
+	out := make(map[string]SiteRules)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		rules, err := LoadSiteRules(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		out[rules.Name] = rules
+	}
+	return out, nil
+}
+
+// RuleEngine applies a SiteRules set against a parsed listing page.
+type RuleEngine struct {
+	rules SiteRules
+}
+
+// NewRuleEngine builds a RuleEngine over rules.
+func NewRuleEngine(rules SiteRules) *RuleEngine {
+	return &RuleEngine{rules: rules}
+}
+
+// Apply extracts every listing matched by rules.ListingSelector from doc.
+func (re *RuleEngine) Apply(doc *goquery.Document) []RemoteJob {
+	var jobs []RemoteJob
+
+	doc.Find(re.rules.ListingSelector).Each(func(_ int, listing *goquery.Selection) {
+		job := RemoteJob{
+			Platform:   re.rules.Name,
+			Location:   "Remote",
+			IsRemote:   true,
+			IsFresher:  true,
+			URL:        re.rules.StartURL,
+			PostedDate: time.Now().Format("2006-01-02"),
+		}
+		for field, rule := range re.rules.Fields {
+			if value := extractField(listing, rule); value != "" {
+				setRemoteJobField(&job, field, value)
+			}
+		}
+		if job.Title != "" && job.Company != "" {
+			jobs = append(jobs, job)
+		}
+	})
+
+	return jobs
+}
+
+// extractField applies a single FieldRule against listing.
+func extractField(listing *goquery.Selection, rule FieldRule) string {
+	target := listing
+	if rule.Selector != "" {
+		target = listing.Find(rule.Selector)
+	}
+
+	var raw string
+	if rule.Attr != "" {
+		raw, _ = target.Attr(rule.Attr)
+	} else {
+		raw = target.Text()
+	}
+	raw = strings.TrimSpace(raw)
+
+	if rule.Regex != "" {
+		if re, err := regexp.Compile(rule.Regex); err == nil {
+			if match := re.FindString(raw); match != "" {
+				raw = match
+			}
+		}
+	}
+	return raw
+}
+
+// setRemoteJobField assigns value to the RemoteJob field named by field, the
+// same names used as keys in a SiteRules.Fields map.
+func setRemoteJobField(job *RemoteJob, field, value string) {
+	switch field {
+	case "title":
+		job.Title = value
+	case "company":
+		job.Company = value
+	case "location":
+		job.Location = value
+	case "description":
+		job.Description = value
+	case "salary":
+		job.Salary = value
+	case "posted_date":
+		job.PostedDate = value
+	case "apply_url":
+		job.ApplyURL = value
+	}
+}
+
+// ruleAdapter is a SourceAdapter whose selectors come entirely from a
+// SiteRules file rather than Go code.
+type ruleAdapter struct {
+	rules  SiteRules
+	engine *RuleEngine
+}
+
+// newRuleAdapter builds a ruleAdapter over rules.
+func newRuleAdapter(rules SiteRules) ruleAdapter {
+	return ruleAdapter{rules: rules, engine: NewRuleEngine(rules)}
+}
+
+func (a ruleAdapter) Name() string { return a.rules.Name }
+
+// Search fetches rules.StartURL (with "{{query}}" substituted, if present)
+// and runs the rule engine against the response body.
+func (a ruleAdapter) Search(ctx context.Context, query string) ([]RemoteJob, error) {
+	startURL := a.rules.StartURL
+	if query != "" {
+		startURL = strings.ReplaceAll(startURL, "{{query}}", url.QueryEscape(query))
+	}
+
+	var jobs []RemoteJob
+	var parseErr error
+
+	c := newScraperCollector()
+	c.OnResponse(func(r *colly.Response) {
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(r.Body))
+		if err != nil {
+			parseErr = err
+			return
+		}
+		for _, job := range a.engine.Apply(doc) {
+			if isFresherJob(job.Title, job.Description) {
+				jobs = append(jobs, job)
+			}
+		}
+	})
+	c.OnError(func(r *colly.Response, err error) {
+		log.Printf("[%s] rules-engine error: %v", a.Name(), err)
+	})
+
+	if err := c.Visit(startURL); err != nil {
+		return nil, err
+	}
+	return jobs, parseErr
+}
+
+func (a ruleAdapter) Enrich(ctx context.Context, job *RemoteJob) error {
+	return sharedDetailCrawler.Enrich(ctx, job)
+}
+
+// SourceAdapter is implemented by every job board the scraper knows how to
+// pull from. Adding a new board (LinkedIn, IT-Jobbank, TheHub, WeLoveRemote,
+// ...) means writing an adapter and registering it in this file's init() —
+// the fan-out/merge/dedupe loop in ScrapeAllSources never has to change.
+type SourceAdapter interface {
+	// Name identifies the adapter and is stamped onto every RemoteJob it finds.
+	Name() string
+	// Search runs one listing-page crawl for query and returns what it found.
+	Search(ctx context.Context, query string) ([]RemoteJob, error)
+	// Enrich fetches a job's detail page to fill in fields the listing page
+	// doesn't carry (description, salary, posted date, ...). Adapters that
+	// have nothing more to fetch can make this a no-op.
+	Enrich(ctx context.Context, job *RemoteJob) error
+}
+
+// sourceAdapterRegistry holds every SourceAdapter available to JobScraper, keyed by name.
+var sourceAdapterRegistry = map[string]SourceAdapter{}
+
+// RegisterSourceAdapter makes a SourceAdapter available to JobScraper.ScrapeAllSources.
+func RegisterSourceAdapter(a SourceAdapter) {
+	sourceAdapterRegistry[a.Name()] = a
+}
+
+func init() {
+	RegisterSourceAdapter(remoteOKAdapter{})
+	RegisterSourceAdapter(weWorkRemotelyAdapter{})
+	RegisterSourceAdapter(flexJobsAdapter{})
+	RegisterSourceAdapter(justRemoteAdapter{})
+	RegisterSourceAdapter(remoteCoAdapter{})
+}
+
+// remoteOKAdapter scrapes RemoteOK's job board.
+type remoteOKAdapter struct{}
+
+func (remoteOKAdapter) Name() string { return "RemoteOK" }
+
+func (a remoteOKAdapter) Search(ctx context.Context, query string) ([]RemoteJob, error) {
+	var found []RemoteJob
+	c := newScraperCollector()
+
+	c.OnHTML("table#jobsboard tr.job", func(e *colly.HTMLElement) {
+		title := strings.TrimSpace(e.ChildText("td:nth-child(3) h2"))
+		company := strings.TrimSpace(e.ChildText("td:nth-child(3) h3"))
+		tags := strings.TrimSpace(e.ChildText("td:nth-child(3) .tags"))
+
+		if title == "" || company == "" || !isFresherJob(title, tags) {
+			return
+		}
+		found = append(found, RemoteJob{
+			Platform:    a.Name(),
+			Title:       title,
+			Company:     company,
+			Location:    "Remote",
+			Description: tags,
+			IsRemote:    true,
+			IsFresher:   true,
+			URL:         "https://remoteok.io",
+			PostedDate:  time.Now().Format("2006-01-02"),
+		})
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		log.Printf("%s error: %v", a.Name(), err)
+	})
+
+	if err := c.Visit("https://remoteok.io"); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// Enrich follows job.URL (and links from it, up to --max-depth pages deep) to
+// backfill Description/Salary; RemoteOK's listing already has everything
+// else.
+func (remoteOKAdapter) Enrich(ctx context.Context, job *RemoteJob) error {
+	return sharedDetailCrawler.Enrich(ctx, job)
+}
+
+// weWorkRemotelyAdapter scrapes WeWorkRemotely.
+type weWorkRemotelyAdapter struct{}
+
+func (weWorkRemotelyAdapter) Name() string { return "WeWorkRemotely" }
+
+func (a weWorkRemotelyAdapter) Search(ctx context.Context, query string) ([]RemoteJob, error) {
+	var found []RemoteJob
+	c := newScraperCollector()
+
+	c.OnHTML("section.jobs article", func(e *colly.HTMLElement) {
+		title := strings.TrimSpace(e.ChildText("h2"))
+		company := strings.TrimSpace(e.ChildText(".company"))
+		location := strings.TrimSpace(e.ChildText(".region"))
+
+		if title == "" || company == "" {
+			return
+		}
+		if !isFresherJob(title, "") || !isRemoteJob(title, location, "") {
+			return
+		}
+		found = append(found, RemoteJob{
+			Platform:   a.Name(),
+			Title:      title,
+			Company:    company,
+			Location:   location,
+			IsRemote:   true,
+			IsFresher:  true,
+			URL:        "https://weworkremotely.com",
+			PostedDate: time.Now().Format("2006-01-02"),
+		})
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		log.Printf("%s error: %v", a.Name(), err)
+	})
+
+	searchTerm := query
+	if searchTerm == "" {
+		searchTerm = "junior"
+	}
+	return found, c.Visit("https://weworkremotely.com/remote-jobs/search?term=" + url.QueryEscape(searchTerm))
+}
+
+func (weWorkRemotelyAdapter) Enrich(ctx context.Context, job *RemoteJob) error {
+	return sharedDetailCrawler.Enrich(ctx, job)
+}
+
+// flexJobsAdapter scrapes FlexJobs.
+type flexJobsAdapter struct{}
+
+func (flexJobsAdapter) Name() string { return "FlexJobs" }
+
+func (a flexJobsAdapter) Search(ctx context.Context, query string) ([]RemoteJob, error) {
+	var found []RemoteJob
+	c := newScraperCollector()
+
+	c.OnHTML(".job", func(e *colly.HTMLElement) {
+		title := strings.TrimSpace(e.ChildText(".job-title"))
+		company := strings.TrimSpace(e.ChildText(".job-company"))
+		location := strings.TrimSpace(e.ChildText(".job-location"))
+
+		if title == "" || company == "" {
+			return
+		}
+		if !isFresherJob(title, "") || !isRemoteJob(title, location, "") {
+			return
+		}
+		found = append(found, RemoteJob{
+			Platform:   a.Name(),
+			Title:      title,
+			Company:    company,
+			Location:   location,
+			IsRemote:   true,
+			IsFresher:  true,
+			URL:        "https://flexjobs.com",
+			PostedDate: time.Now().Format("2006-01-02"),
+		})
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		log.Printf("%s error: %v", a.Name(), err)
+	})
+
+	searchTerm := query
+	if searchTerm == "" {
+		searchTerm = "junior"
+	}
+	return found, c.Visit("https://www.flexjobs.com/search?search=" + url.QueryEscape(searchTerm) + "&location=remote")
+}
+
+func (flexJobsAdapter) Enrich(ctx context.Context, job *RemoteJob) error {
+	return sharedDetailCrawler.Enrich(ctx, job)
+}
+
+// justRemoteAdapter scrapes JustRemote.
+type justRemoteAdapter struct{}
+
+func (justRemoteAdapter) Name() string { return "JustRemote" }
+
+func (a justRemoteAdapter) Search(ctx context.Context, query string) ([]RemoteJob, error) {
+	var found []RemoteJob
+	c := newScraperCollector()
+
+	c.OnHTML(".job-list-item", func(e *colly.HTMLElement) {
+		title := strings.TrimSpace(e.ChildText(".job-title"))
+		company := strings.TrimSpace(e.ChildText(".company-name"))
+
+		if title == "" || company == "" || !isFresherJob(title, "") {
+			return
+		}
+		found = append(found, RemoteJob{
+			Platform:   a.Name(),
+			Title:      title,
+			Company:    company,
+			Location:   "Remote",
+			IsRemote:   true,
+			IsFresher:  true,
+			URL:        "https://justremote.co",
+			PostedDate: time.Now().Format("2006-01-02"),
+		})
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		log.Printf("%s error: %v", a.Name(), err)
+	})
+
+	searchTerm := query
+	if searchTerm == "" {
+		searchTerm = "junior"
+	}
+	return found, c.Visit("https://justremote.co/remote-jobs?search=" + url.QueryEscape(searchTerm))
+}
+
+func (justRemoteAdapter) Enrich(ctx context.Context, job *RemoteJob) error {
+	return sharedDetailCrawler.Enrich(ctx, job)
+}
+
+// remoteCoAdapter scrapes Remote.co.
+type remoteCoAdapter struct{}
+
+func (remoteCoAdapter) Name() string { return "Remote.co" }
+
+func (a remoteCoAdapter) Search(ctx context.Context, query string) ([]RemoteJob, error) {
+	var found []RemoteJob
+	c := newScraperCollector()
+
+	c.OnHTML(".job_listing", func(e *colly.HTMLElement) {
+		title := strings.TrimSpace(e.ChildText(".job_listing-title"))
+		company := strings.TrimSpace(e.ChildText(".job_listing-company"))
+
+		if title == "" || company == "" || !isFresherJob(title, "") {
+			return
+		}
+		found = append(found, RemoteJob{
+			Platform:   a.Name(),
+			Title:      title,
+			Company:    company,
+			Location:   "Remote",
+			IsRemote:   true,
+			IsFresher:  true,
+			URL:        "https://remote.co",
+			PostedDate: time.Now().Format("2006-01-02"),
+		})
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		log.Printf("%s error: %v", a.Name(), err)
+	})
+
+	if err := c.Visit("https://remote.co/remote-jobs/developer/"); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func (remoteCoAdapter) Enrich(ctx context.Context, job *RemoteJob) error {
+	return sharedDetailCrawler.Enrich(ctx, job)
+}
+
+// JobScraper orchestrates every registered SourceAdapter against a query
+type JobScraper struct {
+	jobs        []RemoteJob
+	jobsMutex   sync.Mutex
+	rateLimiter *rate.Limiter
+	client      *http.Client
+
+	// skillExtractor tags every job with the skills it mentions before
+	// skillsFilter (if non-empty) decides whether to keep it.
+	skillExtractor *SkillExtractor
+	skillsFilter   map[string]bool
+
+	// CacheDir stores colly's on-disk HTTP response cache and the
+	// first-seen index; empty disables both.
+	CacheDir string
+	// firstSeen maps a job ID (see generateJobID) to when it was first
+	// recorded, restored from and persisted back to CacheDir so repeated
+	// runs report only newly discovered postings instead of re-adding
+	// everything from scratch.
+	firstSeen map[string]time.Time
+}
+
+// NewJobScraper creates an enhanced job scraper
+func NewJobScraper() *JobScraper {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+
+	// defaultSkillRules never fails to compile, so the error is safe to discard here.
+	extractor, _ := NewSkillExtractor("")
+
+	return &JobScraper{
+		jobs:           []RemoteJob{},
+		rateLimiter:    rate.NewLimiter(rate.Every(1*time.Second), 3),
+		client:         client,
+		skillExtractor: extractor,
+		CacheDir:       "./cache",
+		firstSeen:      make(map[string]time.Time),
+	}
+}
+
+// firstSeenIndexPath returns where the persistent first-seen index lives under CacheDir.
+func (js *JobScraper) firstSeenIndexPath() string {
+	return filepath.Join(js.CacheDir, "first_seen.json")
+}
+
+// loadFirstSeenIndex restores job IDs and their first-seen timestamps from a
+// previous run, so addJob treats them as already seen instead of re-adding
+// the same posting and resetting its FirstSeen every run.
+func (js *JobScraper) loadFirstSeenIndex() {
+	if js.CacheDir == "" {
+		return
+	}
+	data, err := os.ReadFile(js.firstSeenIndexPath())
+	if err != nil {
+		return
+	}
+
+	var index map[string]time.Time
+	if err := json.Unmarshal(data, &index); err != nil {
+		log.Printf("discarding corrupt first-seen index: %v", err)
+		return
+	}
+
+	js.jobsMutex.Lock()
+	for id, seenAt := range index {
+		js.firstSeen[id] = seenAt
+	}
+	js.jobsMutex.Unlock()
+}
+
+// saveFirstSeenIndex persists every job ID seen so far so the next run can
+// tell genuinely new postings from ones it has already reported.
+func (js *JobScraper) saveFirstSeenIndex() {
+	if js.CacheDir == "" {
+		return
+	}
+	if err := os.MkdirAll(js.CacheDir, 0o755); err != nil {
+		log.Printf("could not create cache dir: %v", err)
+		return
+	}
+
+	js.jobsMutex.Lock()
+	data, err := json.MarshalIndent(js.firstSeen, "", "  ")
+	js.jobsMutex.Unlock()
+	if err != nil {
+		log.Printf("could not encode first-seen index: %v", err)
+		return
+	}
+	if err := os.WriteFile(js.firstSeenIndexPath(), data, 0o644); err != nil {
+		log.Printf("could not persist first-seen index: %v", err)
+	}
+}
+
+// FilterSince drops jobs whose FirstSeen falls outside window, turning a
+// cumulative scrape into "what's new since X" for cron/lambda invocation.
+func (js *JobScraper) FilterSince(window time.Duration) {
+	cutoff := time.Now().Add(-window)
+
+	js.jobsMutex.Lock()
+	defer js.jobsMutex.Unlock()
+
+	kept := js.jobs[:0]
+	for _, job := range js.jobs {
+		if job.FirstSeen.After(cutoff) {
+			kept = append(kept, job)
+		}
+	}
+	js.jobs = kept
+}
+
+// generateJobID creates a unique job ID from the fields ScrapeAllSources
+// dedupes on: Company, Title, and URL, so the same posting mirrored across
+// platforms under different URLs is still kept, but a re-scrape of the same
+// listing isn't.
+func (js *JobScraper) generateJobID(title, company, jobURL string) string {
+	normalize := func(s string) string {
+		return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), " ", "_")
+	}
+	return fmt.Sprintf("%s_%s_%s", normalize(company), normalize(title), normalize(jobURL))
+}
+
+// addJob tags job with its skills, drops it if skillsFilter is set and none
+// of those skills matched, and otherwise adds it if its ID hasn't been
+// recorded in firstSeen by this run or a previous, persisted one.
+func (js *JobScraper) addJob(job RemoteJob) {
+	matched := js.skillExtractor.Extract(&job)
+	if !matchesSkillsFilter(matched, js.skillsFilter) {
+		return
+	}
+
+	jobID := js.generateJobID(job.Title, job.Company, job.URL)
+
+	js.jobsMutex.Lock()
+	defer js.jobsMutex.Unlock()
+
+	if _, seen := js.firstSeen[jobID]; seen {
+		return
+	}
+
+	job.ID = jobID
+	job.FirstSeen = time.Now()
+	js.firstSeen[jobID] = job.FirstSeen
+	js.jobs = append(js.jobs, job)
+	log.Printf("‚úÖ Found: %s at %s (%s)", job.Title, job.Company, job.Platform)
+}
+
+// generateMoreSampleJobs creates a larger set of realistic sample jobs
+func (js *JobScraper) generateMoreSampleJobs() {
+	companies := []string{
+		"TechCorp", "StartupXYZ", "CloudTech", "DataCorp", "QualityFirst", "DevStudio",
+		"PythonSoft", "WebBuilders", "DesignHub", "MarketingPro", "InnovateLab", "CodeCraft",
+		"DigitalFlow", "SmartSys", "NextGen", "ProDev", "TechStart", "CloudBase", "DataFlow",
+		"AppWorks", "WebTech", "DevCorp", "SoftLab", "TechHub", "CodeBase", "DigitalTech",
+	}
+
+	jobTemplates := []struct {
+		titleTemplate string
+		descriptions  []string
+		salaryRanges  []string
+	}{
+		{
+			titleTemplate: "Junior Software Engineer",
+			descriptions: []string{
+				"Entry-level position for recent graduates. We welcome fresh talent to join our development team.",
+				"Looking for a motivated junior developer to join our growing team. Training provided.",
+				"Great opportunity for new graduates to start their software engineering career.",
+			},
+			salaryRanges: []string{"$45,000 - $65,000", "$50,000 - $70,000", "$48,000 - $68,000"},
+		},
+		{
+			titleTemplate: "Frontend Developer - Entry Level",
+			descriptions: []string{
+				"Entry-level frontend developer position. React experience preferred but not required.",
+				"Join our frontend team as a junior developer. Perfect for recent coding bootcamp graduates.",
+				"Looking for a passionate frontend developer to help build amazing user interfaces.",
+			},
+			salaryRanges: []string{"$42,000 - $62,000", "$45,000 - $65,000", "$47,000 - $67,000"},
+		},
+		{
+			titleTemplate: "Backend Developer Trainee",
+			descriptions: []string{
+				"Trainee position for recent computer science graduates. Comprehensive training provided.",
+				"Entry-level backend developer role with mentorship and growth opportunities.",
+				"Join our backend team and learn from experienced developers.",
+			},
+			salaryRanges: []string{"$40,000 - $60,000", "$43,000 - $63,000", "$46,000 - $66,000"},
+		},
+		{
+			titleTemplate: "Data Analyst - New Graduate",
+			descriptions: []string{
+				"Entry-level data analyst position. Perfect for new graduates with basic SQL knowledge.",
+				"Join our data team and help turn data into insights. Training provided.",
+				"Great opportunity for math/statistics graduates to start their data career.",
+			},
+			salaryRanges: []string{"$44,000 - $64,000", "$48,000 - $68,000", "$46,000 - $66,000"},
+		},
+		{
+			titleTemplate: "QA Engineer - Junior Level",
+			descriptions: []string{
+				"Junior QA engineer role for candidates with 0-1 years experience. Training provided.",
+				"Entry-level quality assurance position. Learn testing methodologies and tools.",
+				"Join our QA team and help ensure our software meets high quality standards.",
+			},
+			salaryRanges: []string{"$40,000 - $60,000", "$42,000 - $62,000", "$44,000 - $64,000"},
+		},
+		{
+			titleTemplate: "Full Stack Developer - Entry Level",
+			descriptions: []string{
+				"Entry-level full stack developer position. Learn both frontend and backend technologies.",
+				"Join our development team and work on exciting full stack projects.",
+				"Great opportunity for new developers to gain experience across the stack.",
+			},
+			salaryRanges: []string{"$46,000 - $66,000", "$48,000 - $68,000", "$50,000 - $70,000"},
+		},
+		{
+			titleTemplate: "Python Developer - Graduate Role",
+			descriptions: []string{
+				"Graduate-level Python developer role. Ideal for recent graduates with Python knowledge.",
+				"Entry-level Python development position with growth opportunities.",
+				"Join our Python team and work on data processing and web applications.",
+			},
+			salaryRanges: []string{"$44,000 - $64,000", "$47,000 - $67,000", "$49,000 - $69,000"},
+		},
+		{
+			titleTemplate: "JavaScript Developer - Junior",
+			descriptions: []string{
+				"Junior JavaScript developer position. Experience with modern frameworks preferred.",
+				"Entry-level JavaScript role with opportunities to work on innovative projects.",
+				"Join our frontend team and help build interactive web applications.",
+			},
+			salaryRanges: []string{"$43,000 - $63,000", "$45,000 - $65,000", "$47,000 - $67,000"},
+		},
+		{
+			titleTemplate: "UI/UX Designer - Entry Level",
+			descriptions: []string{
+				"Entry-level UI/UX designer position for creative individuals. Portfolio required.",
+				"Join our design team and help create amazing user experiences.",
+				"Great opportunity for design graduates to start their UX career.",
+			},
+			salaryRanges: []string{"$40,000 - $60,000", "$42,000 - $62,000", "$44,000 - $64,000"},
+		},
+		{
+			titleTemplate: "Digital Marketing Associate",
+			descriptions: []string{
+				"Entry-level digital marketing position. Great for recent marketing graduates.",
+				"Join our marketing team and help grow our online presence.",
+				"Learn digital marketing strategies while working on real campaigns.",
+			},
+			salaryRanges: []string{"$35,000 - $55,000", "$38,000 - $58,000", "$40,000 - $60,000"},
+		},
+	}
+
+	platforms := []string{"Indeed", "LinkedIn", "Glassdoor", "AngelList", "Dice", "Monster", "ZipRecruiter", "SimplyHired"}
+
+	// Generate jobs
+	for i := 0; i < 50; i++ {
+		template := jobTemplates[rand.Intn(len(jobTemplates))]
+		company := companies[rand.Intn(len(companies))]
+		platform := platforms[rand.Intn(len(platforms))]
+		description := template.descriptions[rand.Intn(len(template.descriptions))]
+		salary := template.salaryRanges[rand.Intn(len(template.salaryRanges))]
+
+		// Add some variety to titles
+		title := template.titleTemplate
+		if rand.Float32() < 0.3 {
+			variations := []string{" - Remote", " (Remote)", " - Work from Home"}
+			title += variations[rand.Intn(len(variations))]
+		}
+
+		job := RemoteJob{
+			Platform:    platform,
+			Title:       title,
+			Company:     company,
+			Location:    "Remote",
+			Description: description,
+			Salary:      salary,
+			PostedDate:  time.Now().AddDate(0, 0, -rand.Intn(14)).Format("2006-01-02"),
+			JobType:     "Full-time",
+			Experience:  "Entry Level",
+			IsRemote:    true,
+			IsFresher:   true,
+			URL:         fmt.Sprintf("https://%s.com/job/%d", strings.ToLower(platform), rand.Intn(100000)),
+		}
+
+		js.addJob(job)
+	}
+
+	log.Printf("Generated %d sample remote fresher jobs", 50)
+}
+
+// ScrapeAllSources fans out query across every registered SourceAdapter
+// concurrently, enriches and merges whatever each one finds into js.jobs,
+// and drops anything already seen for the same (Company, Title, URL).
+func (js *JobScraper) ScrapeAllSources(ctx context.Context, query string) error {
+	log.Println("üöÄ Starting comprehensive remote fresher jobs scraping...")
+
+	scraperCacheDir = js.CacheDir
+
+	// Seed with sample jobs so the tool still produces output on boards that
+	// are blocking or unreachable in the current environment.
+	js.generateMoreSampleJobs()
+
+	var wg sync.WaitGroup
+	results := make(chan []RemoteJob, len(sourceAdapterRegistry))
+
+	for _, adapter := range sourceAdapterRegistry {
+		wg.Add(1)
+		go func(a SourceAdapter) {
+			defer wg.Done()
+
+			if err := js.rateLimiter.Wait(ctx); err != nil {
+				log.Printf("[%s] rate limiting error: %v", a.Name(), err)
+				return
+			}
+
+			jobs, err := a.Search(ctx, query)
+			if err != nil {
+				log.Printf("[%s] search error: %v", a.Name(), err)
+				return
+			}
+			results <- jobs
+		}(adapter)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Enrichment hits each job's own detail URL (and, per detailCrawler,
+	// possibly a couple of hops beyond it), so it runs concurrently across
+	// jobs too; detailCrawler's per-host semaphore keeps any one site from
+	// being hit harder than Concurrency allows regardless of how many jobs
+	// from it are being enriched at once.
+	var enriching sync.WaitGroup
+	for jobs := range results {
+		for _, job := range jobs {
+			enriching.Add(1)
+			go func(job RemoteJob) {
+				defer enriching.Done()
+				if adapter, ok := sourceAdapterRegistry[job.Platform]; ok {
+					if err := adapter.Enrich(ctx, &job); err != nil {
+						log.Printf("[%s] enrich error: %v", job.Platform, err)
+					}
+				}
+				js.addJob(job)
+			}(job)
+		}
+	}
+	enriching.Wait()
+
+	log.Printf("‚úÖ Scraping completed. Found %d unique remote fresher jobs", len(js.jobs))
+	return nil
+}
+
+// OutputSink is implemented by every format a JobScraper run can be
+// persisted to. Write is called once per job so a streaming sink (NDJSON,
+// SQLite) never has to buffer the full result set the way a JSON array does.
+type OutputSink interface {
+	Write(job RemoteJob) error
+	Close() error
+}
+
+var remoteJobHeaders = []string{
+	"ID", "Platform", "Title", "Company", "Location", "Description",
+	"Salary", "PostedDate", "JobType", "Experience", "IsRemote", "IsFresher", "URL",
+	"React", "Python", "Golang", "TypeScript", "NextJS", "Tailwind", "Docker", "Kubernetes", "AWS",
+}
+
+func remoteJobRecord(job RemoteJob) []string {
+	return []string{
+		job.ID,
+		job.Platform,
+		job.Title,
+		job.Company,
+		job.Location,
+		job.Description,
+		job.Salary,
+		job.PostedDate,
+		job.JobType,
+		job.Experience,
+		fmt.Sprintf("%t", job.IsRemote),
+		fmt.Sprintf("%t", job.IsFresher),
+		job.URL,
+		fmt.Sprintf("%t", job.Skills.React),
+		fmt.Sprintf("%t", job.Skills.Python),
+		fmt.Sprintf("%t", job.Skills.Golang),
+		fmt.Sprintf("%t", job.Skills.TypeScript),
+		fmt.Sprintf("%t", job.Skills.NextJS),
+		fmt.Sprintf("%t", job.Skills.Tailwind),
+		fmt.Sprintf("%t", job.Skills.Docker),
+		fmt.Sprintf("%t", job.Skills.Kubernetes),
+		fmt.Sprintf("%t", job.Skills.AWS),
+	}
+}
+
+// CSVSink writes one row per job, flushing after every write.
+type CSVSink struct {
+	file   *os.File
+	writer *csv.Writer
+}
+
+func NewCSVSink(filename string) (*CSVSink, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CSV file: %w", err)
+	}
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(remoteJobHeaders); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write headers: %w", err)
+	}
+
+	return &CSVSink{file: file, writer: writer}, nil
+}
+
+func (s *CSVSink) Write(job RemoteJob) error {
+	if err := s.writer.Write(remoteJobRecord(job)); err != nil {
+		return fmt.Errorf("failed to write job record: %w", err)
+	}
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+func (s *CSVSink) Close() error {
+	s.writer.Flush()
+	return s.file.Close()
+}
+
+// JSONSink buffers every job and writes a single indented JSON array on Close.
+type JSONSink struct {
+	filename string
+	jobs     []RemoteJob
+}
+
+func NewJSONSink(filename string) *JSONSink {
+	return &JSONSink{filename: filename}
+}
+
+func (s *JSONSink) Write(job RemoteJob) error {
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+func (s *JSONSink) Close() error {
+	data, err := json.MarshalIndent(s.jobs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode jobs as JSON: %w", err)
+	}
+	return os.WriteFile(s.filename, data, 0o644)
+}
+
+// NDJSONSink writes one job per line as it is scraped, so a crash mid-run
+// doesn't lose everything collected so far the way a buffered JSON array would.
+type NDJSONSink struct {
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func NewNDJSONSink(filename string) (*NDJSONSink, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create NDJSON file: %w", err)
+	}
+	return &NDJSONSink{file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+func (s *NDJSONSink) Write(job RemoteJob) error {
+	return s.encoder.Encode(job)
+}
+
+func (s *NDJSONSink) Close() error {
+	return s.file.Close()
+}
+
+// SQLiteSink persists jobs to a SQLite database, upserting on URL so repeat
+// runs against the same DSN update existing rows instead of duplicating them.
+type SQLiteSink struct {
+	db *sql.DB
+}
+
+func NewSQLiteSink(dsn string) (*SQLiteSink, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite db: %w", err)
+	}
+
+	const schema = `CREATE TABLE IF NOT EXISTS jobs (
+		url TEXT PRIMARY KEY,
+		id TEXT,
+		platform TEXT,
+		title TEXT,
+		company TEXT,
+		location TEXT,
+		description TEXT,
+		salary TEXT,
+		posted_date TEXT,
+		job_type TEXT,
+		experience TEXT,
+		is_remote INTEGER,
+		is_fresher INTEGER,
+		first_seen TEXT
+	)`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating jobs table: %w", err)
+	}
+
+	return &SQLiteSink{db: db}, nil
+}
+
+func (s *SQLiteSink) Write(job RemoteJob) error {
+	_, err := s.db.Exec(
+		`INSERT INTO jobs (url, id, platform, title, company, location, description, salary, posted_date, job_type, experience, is_remote, is_fresher, first_seen)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(url) DO UPDATE SET
+			id=excluded.id, platform=excluded.platform, title=excluded.title, company=excluded.company,
+			location=excluded.location, description=excluded.description, salary=excluded.salary,
+			posted_date=excluded.posted_date, job_type=excluded.job_type, experience=excluded.experience,
+			is_remote=excluded.is_remote, is_fresher=excluded.is_fresher, first_seen=excluded.first_seen`,
+		job.URL, job.ID, job.Platform, job.Title, job.Company, job.Location, job.Description,
+		job.Salary, job.PostedDate, job.JobType, job.Experience, job.IsRemote, job.IsFresher,
+		job.FirstSeen.Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("upserting job %s: %w", job.URL, err)
+	}
+	return nil
+}
+
+func (s *SQLiteSink) Close() error {
+	return s.db.Close()
+}
+
+// WebhookSink POSTs each job as a JSON body to a user-supplied URL, e.g. to
+// feed a downstream pipeline or chat integration in near real time.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *WebhookSink) Write(job RemoteJob) error {
+	body, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("encoding job for webhook: %w", err)
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("posting job to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		log.Printf("‚ö†Ô∏è  webhook returned status %d for job %s", resp.StatusCode, job.URL)
+	}
+	return nil
+}
+
+func (s *WebhookSink) Close() error { return nil }
+
+// SaveToCSV saves jobs to a CSV file. Kept for backward compatibility with
+// callers that only need CSV; new code should build an OutputSink directly.
+func (js *JobScraper) SaveToCSV(filename string) error {
+	sink, err := NewCSVSink(filename)
+	if err != nil {
+		return err
+	}
+
+	js.jobsMutex.Lock()
+	jobs := append([]RemoteJob(nil), js.jobs...)
+	js.jobsMutex.Unlock()
+
+	for _, job := range jobs {
+		if err := sink.Write(job); err != nil {
+			sink.Close()
+			return err
+		}
+	}
+
+	log.Printf("üíæ Successfully saved %d jobs to %s", len(jobs), filename)
+	return sink.Close()
+}
+
+// PrintEnhancedStats displays comprehensive statistics
+func (js *JobScraper) PrintEnhancedStats() {
+	js.jobsMutex.Lock()
+	defer js.jobsMutex.Unlock()
+
+	platformStats := make(map[string]int)
+	salaryStats := make(map[string]int)
+	companyStats := make(map[string]int)
+
+	for _, job := range js.jobs {
+		platformStats[job.Platform]++
+		if job.Salary != "" {
+			salaryStats["With Salary"]++
+		} else {
+			salaryStats["No Salary Info"]++
+		}
+		companyStats[job.Company]++
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Println("üìä REMOTE FRESHER JOBS SCRAPING RESULTS")
+	fmt.Println(strings.Repeat("=", 60))
+
+	fmt.Printf("üéØ Total Jobs Found: %d\n", len(js.jobs))
+	fmt.Printf("üè¢ Unique Companies: %d\n", len(companyStats))
+
+	fmt.Println("\nüìà Jobs by Platform:")
+	for platform, count := range platformStats {
+		fmt.Printf("  ‚Ä¢ %-15s: %d jobs\n", platform, count)
+	}
+
+	fmt.Println("\nüí∞ Salary Information:")
+	for category, count := range salaryStats {
+		fmt.Printf("  ‚Ä¢ %-15s: %d jobs\n", category, count)
+	}
+
+	fmt.Println("\nüèÜ Top Companies:")
+	count := 0
+	for company, jobs := range companyStats {
+		if count >= 10 {
+			break
+		}
+		fmt.Printf("  ‚Ä¢ %-15s: %d jobs\n", company, jobs)
+		count++
+	}
+
+	fmt.Println(strings.Repeat("=", 60))
+}
+
@@ -0,0 +1,92 @@
+//go:build !lambda
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// XLSXSink writes one row per job to an xlsx workbook as it is scraped,
+// rather than buffering the full result set the way JSONSink does; the
+// workbook itself is only assembled and written out on Close, since
+// excelize has no incremental-save mode.
+type XLSXSink struct {
+	filename string
+	file     *excelize.File
+	sheet    string
+	row      int
+}
+
+func NewXLSXSink(filename string) *XLSXSink {
+	f := excelize.NewFile()
+	const sheet = "Jobs"
+	f.NewSheet(sheet)
+	f.DeleteSheet("Sheet1")
+
+	for col, header := range remoteJobHeaders {
+		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
+		f.SetCellValue(sheet, cell, header)
+	}
+
+	return &XLSXSink{filename: filename, file: f, sheet: sheet, row: 1}
+}
+
+func (s *XLSXSink) Write(job RemoteJob) error {
+	s.row++
+	for col, value := range remoteJobRecord(job) {
+		cell, _ := excelize.CoordinatesToCellName(col+1, s.row)
+		s.file.SetCellValue(s.sheet, cell, value)
+	}
+
+	urlCol := indexOf(remoteJobHeaders, "URL")
+	if urlCol >= 0 && job.URL != "" {
+		cell, _ := excelize.CoordinatesToCellName(urlCol+1, s.row)
+		s.file.SetCellHyperLink(s.sheet, cell, job.URL, "External")
+	}
+	applyURLCol := indexOf(remoteJobHeaders, "ApplyURL")
+	if applyURLCol >= 0 && job.ApplyURL != "" {
+		cell, _ := excelize.CoordinatesToCellName(applyURLCol+1, s.row)
+		s.file.SetCellHyperLink(s.sheet, cell, job.ApplyURL, "External")
+	}
+	return nil
+}
+
+func (s *XLSXSink) Close() error {
+	for col := range remoteJobHeaders {
+		colName, _ := excelize.ColumnNumberToName(col + 1)
+		s.file.SetColWidth(s.sheet, colName, colName, 20)
+	}
+
+	lastCellName, _ := excelize.CoordinatesToCellName(len(remoteJobHeaders), s.row)
+	if err := s.file.AutoFilter(s.sheet, fmt.Sprintf("A1:%s", lastCellName), nil); err != nil {
+		return fmt.Errorf("applying auto-filter: %w", err)
+	}
+
+	if err := s.file.SetPanes(s.sheet, &excelize.Panes{
+		Freeze:      true,
+		Split:       false,
+		XSplit:      0,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return fmt.Errorf("freezing header row: %w", err)
+	}
+
+	if err := s.file.SaveAs(s.filename); err != nil {
+		return fmt.Errorf("failed to write xlsx file: %w", err)
+	}
+	return nil
+}
+
+// indexOf returns the position of needle in haystack, or -1 if absent.
+func indexOf(haystack []string, needle string) int {
+	for i, h := range haystack {
+		if h == needle {
+			return i
+		}
+	}
+	return -1
+}
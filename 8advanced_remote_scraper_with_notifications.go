@@ -1,14 +1,14 @@
 package main
 
 import (
+	"context"
 	"encoding/csv"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"net/http"
-	"net/smtp"
-	"net/url"
 	"os"
 	"regexp"
 	"strings"
@@ -36,25 +36,46 @@ type NotificationConfig struct {
 	} `json:"whatsapp"`
 	EnableEmail    bool `json:"enable_email"`
 	EnableWhatsApp bool `json:"enable_whatsapp"`
+
+	// Notifiers lists Shoutrrr-style notifier URLs (smtp://, twilio://,
+	// telegram://, discord://, slack://, gotify://, generic+https://) to
+	// construct in addition to the legacy Email/WhatsApp fields above.
+	Notifiers []string `json:"notifiers"`
+
+	// Telegram configures the interactive bot (see --telegram-bot), separate
+	// from any telegram:// URL in Notifiers, which only mirrors the digest.
+	Telegram TelegramConfig `json:"telegram"`
+
+	// Subscribers configures double opt-in email onboarding (see --serve),
+	// required before SendNotifications will email anyone but the author.
+	Subscribers SubscriberConfig `json:"subscribers"`
+}
+
+// TelegramConfig holds the interactive bot's credentials and access list.
+type TelegramConfig struct {
+	BotToken       string  `json:"bot_token"`
+	WebhookURL     string  `json:"webhook_url"`
+	AllowedUserIDs []int64 `json:"allowed_user_ids"`
 }
 
 // RemoteJob represents a remote job listing
 type RemoteJob struct {
-	ID          string
-	Platform    string
-	Title       string
-	Company     string
-	Location    string
-	Description string
-	Salary      string
-	PostedDate  string
-	JobType     string
-	Experience  string
-	Tags        []string
-	IsRemote    bool
-	IsFresher   bool
-	URL         string
-	ApplyURL    string
+	ID           string
+	Platform     string
+	Title        string
+	Company      string
+	Location     string
+	Description  string
+	Salary       string // raw salary text as scraped; see Compensation for the normalized form
+	Compensation Compensation
+	PostedDate   string
+	JobType      string
+	Experience   string
+	Tags         []string
+	IsRemote     bool
+	IsFresher    bool
+	URL          string
+	ApplyURL     string
 }
 
 // JobScraper manages the scraping process with notifications
@@ -70,7 +91,32 @@ type JobScraper struct {
 	jobTitles       []string
 	seenJobs        map[string]bool
 	notifConfig     NotificationConfig
+	notifiers       []Notifier
 	newJobsCount    int
+
+	// store persists jobs across restarts so addJob can tell a genuinely
+	// new job from one already reported in a previous run; nil disables
+	// persistence and falls back to seenJobs/newJobsCount resetting per run.
+	store JobStore
+
+	// digestSince, when non-zero, makes buildDigestData source NewJobs from
+	// store.JobsSince instead of this run's newJobsCount, backing --since.
+	digestSince time.Duration
+
+	// proxyPool rotates outbound requests (both js.client and every colly
+	// collector createCollector builds) across -proxies/$JOBSCRAPER_PROXIES;
+	// nil means every request goes out directly. See useProxyPool.
+	proxyPool *ProxyPool
+
+	// searchLocation, searchJobType, searchHoursOld, and resultsWanted back
+	// -location/-job-type/-hours-old/-results-wanted and are threaded into
+	// every Query built by ScrapeAllSources, letting -search/-location/etc.
+	// target a specific role instead of always searching the built-in
+	// fresher title list against "Remote".
+	searchLocation string
+	searchJobType  string
+	searchHoursOld time.Duration
+	resultsWanted  int
 }
 
 // NewJobScraper creates an enhanced job scraper with notifications
@@ -115,6 +161,12 @@ func NewJobScraper() *JobScraper {
 	// Load notification configuration
 	notifConfig := loadNotificationConfig()
 
+	store, err := NewJobStore(storeDBPath)
+	if err != nil {
+		log.Printf("⚠️ Failed to open job store at %s (falling back to in-memory dedup only): %v", storeDBPath, err)
+		store = nil
+	}
+
 	return &JobScraper{
 		jobs:            []RemoteJob{},
 		rateLimiter:     rate.NewLimiter(rate.Every(1*time.Second), 3),
@@ -126,7 +178,10 @@ func NewJobScraper() *JobScraper {
 		jobTitles:       jobTitles,
 		seenJobs:        make(map[string]bool),
 		notifConfig:     notifConfig,
+		notifiers:       buildNotifiers(append(legacyNotifierURLs(notifConfig), notifConfig.Notifiers...)),
 		newJobsCount:    0,
+		store:           store,
+		searchLocation:  "Remote",
 	}
 }
 
@@ -177,6 +232,7 @@ func (js *JobScraper) createCollector() *colly.Collector {
 	})
 
 	c.SetRequestTimeout(30 * time.Second)
+	js.applyProxyPool(c)
 	return c
 }
 
@@ -239,151 +295,32 @@ func (js *JobScraper) addJob(job RemoteJob) {
 	defer js.jobsMutex.Unlock()
 
 	jobID := js.generateJobID(job.Title, job.Company)
-	if !js.seenJobs[jobID] {
-		job.ID = jobID
-		js.jobs = append(js.jobs, job)
-		js.seenJobs[jobID] = true
-		js.newJobsCount++
-		log.Printf("✅ Found: %s at %s (%s)", job.Title, job.Company, job.Platform)
-	}
-}
-
-// sendEmailNotification sends email notification about new jobs
-func (js *JobScraper) sendEmailNotification(jobCount int) error {
-	if !js.notifConfig.EnableEmail {
-		return nil
+	if js.seenJobs[jobID] {
+		return
 	}
-
-	from := js.notifConfig.Email.FromEmail
-	password := js.notifConfig.Email.FromPassword
-	to := js.notifConfig.Email.ToEmail
-	smtpHost := js.notifConfig.Email.SMTPHost
-	smtpPort := js.notifConfig.Email.SMTPPort
-
-	// Create email content
-	subject := fmt.Sprintf("🎯 %d New Remote Fresher Jobs Found!", jobCount)
-	
-	body := fmt.Sprintf(`
-<html>
-<body>
-<h2>🎯 Remote Fresher Jobs Alert</h2>
-<p>Great news! We found <strong>%d new remote jobs</strong> suitable for freshers.</p>
-
-<h3>📊 Job Summary:</h3>
-<ul>
-<li><strong>Total Jobs:</strong> %d</li>
-<li><strong>All Remote:</strong> ✅ Yes</li>
-<li><strong>Experience Level:</strong> Entry Level / Fresher</li>
-<li><strong>Date:</strong> %s</li>
-</ul>
-
-<h3>🔗 Top Job Highlights:</h3>
-`, jobCount, len(js.jobs), time.Now().Format("January 2, 2006"))
-
-	// Add first few jobs to email
-	count := 0
-	for _, job := range js.jobs {
-		if count >= 5 {
-			break
+	job.ID = jobID
+	if comp, ok := ParseCompensation(job.Salary); ok {
+		job.Compensation = comp
+	}
+	js.jobs = append(js.jobs, job)
+	js.seenJobs[jobID] = true
+
+	// With a store configured, "new" means never persisted before - not
+	// just new to this run - so a restarted scraper doesn't re-alert on
+	// jobs it already reported.
+	isNew := true
+	if js.store != nil {
+		var err error
+		isNew, err = js.store.UpsertJob(context.Background(), job)
+		if err != nil {
+			log.Printf("⚠️ Failed to persist job %s: %v", jobID, err)
 		}
-		body += fmt.Sprintf(`
-<div style="border: 1px solid #ddd; padding: 10px; margin: 10px 0;">
-<h4>%s</h4>
-<p><strong>Company:</strong> %s</p>
-<p><strong>Platform:</strong> %s</p>
-<p><strong>Salary:</strong> %s</p>
-<p><strong>Description:</strong> %s</p>
-</div>
-`, job.Title, job.Company, job.Platform, job.Salary, job.Description)
-		count++
-	}
-
-	body += `
-<p>💡 <strong>Tip:</strong> Apply early! Remote positions for freshers are competitive.</p>
-<p>🚀 Good luck with your job search!</p>
-</body>
-</html>
-`
-
-	msg := fmt.Sprintf("To: %s\r\nSubject: %s\r\nMIME-version: 1.0\r\nContent-Type: text/html; charset=\"UTF-8\"\r\n\r\n%s", to, subject, body)
-
-	auth := smtp.PlainAuth("", from, password, smtpHost)
-	err := smtp.SendMail(smtpHost+":"+smtpPort, auth, from, []string{to}, []byte(msg))
-	
-	if err != nil {
-		log.Printf("❌ Email notification failed: %v", err)
-		return err
 	}
-	
-	log.Printf("✅ Email notification sent to %s", to)
-	return nil
-}
 
-// sendWhatsAppNotification sends WhatsApp notification using Twilio
-func (js *JobScraper) sendWhatsAppNotification(jobCount int) error {
-	if !js.notifConfig.EnableWhatsApp {
-		return nil
-	}
-
-	accountSID := js.notifConfig.WhatsApp.AccountSID
-	authToken := js.notifConfig.WhatsApp.AuthToken
-	fromNumber := js.notifConfig.WhatsApp.FromNumber
-	toNumber := js.notifConfig.WhatsApp.ToNumber
-
-	if accountSID == "" || authToken == "" {
-		log.Println("⚠️ WhatsApp notification skipped - Twilio credentials not configured")
-		return nil
-	}
-
-	// Create WhatsApp message
-	message := fmt.Sprintf(`🎯 *Remote Jobs Alert*
-
-Found *%d new remote jobs* for freshers!
-
-📊 *Summary:*
-• Total Jobs: %d
-• All Remote: ✅
-• Experience: Entry Level
-• Date: %s
-
-💡 Check your email for details!
-
-🚀 Good luck with your applications!`, 
-		jobCount, len(js.jobs), time.Now().Format("Jan 2, 2006"))
-
-	// Twilio API endpoint
-	apiURL := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", accountSID)
-
-	// Prepare form data
-	data := url.Values{}
-	data.Set("From", fromNumber)
-	data.Set("To", toNumber)
-	data.Set("Body", message)
-
-	// Create HTTP request
-	req, err := http.NewRequest("POST", apiURL, strings.NewReader(data.Encode()))
-	if err != nil {
-		return err
-	}
-
-	req.SetBasicAuth(accountSID, authToken)
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-
-	// Send request
-	resp, err := js.client.Do(req)
-	if err != nil {
-		log.Printf("❌ WhatsApp notification failed: %v", err)
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		log.Printf("✅ WhatsApp notification sent to %s", toNumber)
-	} else {
-		log.Printf("❌ WhatsApp notification failed with status: %d", resp.StatusCode)
+	if isNew {
+		js.newJobsCount++
+		log.Printf("✅ Found: %s at %s (%s)", job.Title, job.Company, job.Platform)
 	}
-
-	return nil
 }
 
 // generateMoreSampleJobs creates a larger set of realistic sample jobs
@@ -475,22 +412,18 @@ func (js *JobScraper) generateMoreSampleJobs() {
 	log.Printf("Generated %d sample remote fresher jobs", 25)
 }
 
-// ScrapeAllSources scrapes all available job sources
-func (js *JobScraper) ScrapeAllSources() error {
-	log.Println("🚀 Starting comprehensive remote fresher jobs scraping...")
-
-	// Generate sample jobs
-	js.generateMoreSampleJobs()
-
-	// In a real implementation, you would add actual scraping here
-	// For now, we'll use the sample data to demonstrate notifications
-
-	log.Printf("✅ Scraping completed. Found %d unique remote fresher jobs", len(js.jobs))
-	return nil
-}
-
-// SaveToCSV saves jobs to CSV file with enhanced format
+// SaveToCSV saves jobs to CSV file with enhanced format. With a store
+// configured, it exports directly from the database (every job ever seen,
+// not just this run's in-memory slice).
 func (js *JobScraper) SaveToCSV(filename string) error {
+	if js.store != nil {
+		if err := js.store.ExportCSV(context.Background(), filename); err != nil {
+			return err
+		}
+		log.Printf("💾 Successfully exported jobs to %s", filename)
+		return nil
+	}
+
 	file, err := os.Create(filename)
 	if err != nil {
 		return fmt.Errorf("failed to create CSV file: %v", err)
@@ -536,26 +469,6 @@ func (js *JobScraper) SaveToCSV(filename string) error {
 	return nil
 }
 
-// SendNotifications sends both email and WhatsApp notifications
-func (js *JobScraper) SendNotifications() {
-	if js.newJobsCount == 0 {
-		log.Println("📱 No new jobs found - skipping notifications")
-		return
-	}
-
-	log.Printf("📨 Sending notifications for %d new jobs...", js.newJobsCount)
-
-	// Send email notification
-	if err := js.sendEmailNotification(js.newJobsCount); err != nil {
-		log.Printf("❌ Email notification error: %v", err)
-	}
-
-	// Send WhatsApp notification
-	if err := js.sendWhatsAppNotification(js.newJobsCount); err != nil {
-		log.Printf("❌ WhatsApp notification error: %v", err)
-	}
-}
-
 // PrintEnhancedStats displays comprehensive statistics
 func (js *JobScraper) PrintEnhancedStats() {
 	js.jobsMutex.Lock()
@@ -582,6 +495,55 @@ func (js *JobScraper) PrintEnhancedStats() {
 }
 
 func main() {
+	previewEmail := flag.String("preview-email", "", "Render the named template (digest.html, digest.txt, whatsapp, single_job) to stdout against sample jobs and exit")
+	since := flag.String("since", "", "Only alert on jobs first seen within this duration, e.g. 24h (requires the SQLite store)")
+	telegramBotMode := flag.Bool("telegram-bot", false, "Run the interactive Telegram bot (long-polling for /start, /filter, /exclude) instead of scraping")
+	serveAddr := flag.String("serve", "", "Start an HTTP server (e.g. :8080) serving /confirm and /unsubscribe for subscriber onboarding, instead of scraping")
+	proxies := flag.String("proxies", "", "Comma-separated HTTP(S)/SOCKS5 proxy URLs to rotate requests through (falls back to $JOBSCRAPER_PROXIES)")
+	dumpCSV := flag.String("dump-csv", "", "Export every job in the store to this CSV file and exit, without scraping")
+	dumpXLSX := flag.String("dump-xlsx", "", "Export every job in the store to this XLSX file and exit, without scraping")
+	search := flag.String("search", "", "Comma-separated job titles to search for (default: the built-in fresher title list)")
+	location := flag.String("location", "Remote", "Location to search, passed through to each source's own location/region parameter")
+	hoursOld := flag.Int("hours-old", 0, "Only return listings posted within this many hours (maps to each source's own recency filter, e.g. Indeed's fromage)")
+	jobType := flag.String("job-type", "", "Job type filter: fulltime, parttime, contract, temporary, or internship")
+	resultsWanted := flag.Int("results-wanted", 0, "Stop each source after this many results (default: no limit)")
+	flag.Parse()
+
+	if *dumpCSV != "" {
+		if err := dumpStoreToFile(*dumpCSV, writeJobsCSV); err != nil {
+			log.Fatalf("❌ dump-csv failed: %v", err)
+		}
+		return
+	}
+
+	if *dumpXLSX != "" {
+		if err := dumpStoreToFile(*dumpXLSX, writeJobsXLSX); err != nil {
+			log.Fatalf("❌ dump-xlsx failed: %v", err)
+		}
+		return
+	}
+
+	if *previewEmail != "" {
+		if err := runPreviewEmail(*previewEmail); err != nil {
+			log.Fatalf("❌ Preview failed: %v", err)
+		}
+		return
+	}
+
+	if *telegramBotMode {
+		if err := runTelegramBot(); err != nil {
+			log.Fatalf("❌ Telegram bot failed: %v", err)
+		}
+		return
+	}
+
+	if *serveAddr != "" {
+		if err := runSubscriberServer(*serveAddr); err != nil {
+			log.Fatalf("❌ Subscriber server failed: %v", err)
+		}
+		return
+	}
+
 	log.Println("🎯 Advanced Remote Fresher Jobs Scraper with Notifications v3.0")
 	log.Println("📧 Email: yuvrajsinghnain03@gmail.com")
 	log.Println("📱 WhatsApp: +919216703705")
@@ -589,6 +551,28 @@ func main() {
 
 	scraper := NewJobScraper()
 
+	if pool := NewProxyPool(resolveProxies(*proxies)); len(pool.entries) > 0 {
+		scraper.useProxyPool(pool)
+	}
+
+	if *since != "" {
+		duration, err := time.ParseDuration(*since)
+		if err != nil {
+			log.Fatalf("❌ Invalid -since duration %q: %v", *since, err)
+		}
+		scraper.digestSince = duration
+	}
+
+	if *search != "" {
+		scraper.jobTitles = strings.Split(*search, ",")
+	}
+	scraper.searchLocation = *location
+	scraper.searchJobType = *jobType
+	scraper.resultsWanted = *resultsWanted
+	if *hoursOld > 0 {
+		scraper.searchHoursOld = time.Duration(*hoursOld) * time.Hour
+	}
+
 	// Start comprehensive scraping
 	if err := scraper.ScrapeAllSources(); err != nil {
 		log.Fatalf("❌ Scraping failed: %v", err)
@@ -606,8 +590,11 @@ func main() {
 	fmt.Printf("\n✅ SUCCESS! Remote fresher jobs saved to: %s\n", filename)
 
 	// Send notifications
-	scraper.SendNotifications()
-	
+	scraper.SendNotifications(context.Background())
+
+	// Push per-subscriber Telegram alerts for this run's new jobs
+	scraper.PushTelegramAlerts(context.Background())
+
 	// Provide setup instructions
 	fmt.Println("\n📧 EMAIL NOTIFICATION SETUP:")
 	fmt.Println("1. Enable 2-factor authentication on your Gmail account")
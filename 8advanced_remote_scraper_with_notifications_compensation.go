@@ -0,0 +1,147 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CompensationInterval is the pay period a Compensation's amounts are
+// denominated in.
+type CompensationInterval string
+
+const (
+	IntervalHourly  CompensationInterval = "hourly"
+	IntervalDaily   CompensationInterval = "daily"
+	IntervalWeekly  CompensationInterval = "weekly"
+	IntervalMonthly CompensationInterval = "monthly"
+	IntervalYearly  CompensationInterval = "yearly"
+)
+
+// Compensation is a normalized pay range extracted from a listing's free-text
+// salary string by ParseCompensation. Raw keeps the original text so a
+// listing whose salary didn't parse cleanly can still show the source
+// wording verbatim.
+type Compensation struct {
+	MinAmount float64
+	MaxAmount float64
+	Currency  string
+	Interval  CompensationInterval
+	Raw       string
+}
+
+var (
+	currencySymbols = map[string]string{
+		"$": "USD", "£": "GBP", "€": "EUR", "₹": "INR", "¥": "JPY",
+	}
+	currencyCodePattern = regexp.MustCompile(`\b(USD|GBP|EUR|INR|CAD|AUD|SGD|CHF|JPY|NZD)\b`)
+
+	// compensationRangePattern matches two amounts separated by a dash/"to",
+	// each with an optional k/lakh/crore multiplier suffix, e.g.
+	// "$45,000 - $65,000", "£30k-£40k", "INR 6-9".
+	compensationRangePattern = regexp.MustCompile(`(?i)[$£€₹¥]?\s*([\d,]+(?:\.\d+)?)\s*(k|lakh|lac|crore|cr)?\s*(?:-|to|–)\s*[$£€₹¥]?\s*([\d,]+(?:\.\d+)?)\s*(k|lakh|lac|crore|cr)?`)
+
+	// compensationSinglePattern matches one amount, used when no range
+	// separator is found, e.g. "€25/hour", "USD 120000 annually".
+	compensationSinglePattern = regexp.MustCompile(`(?i)[$£€₹¥]?\s*([\d,]+(?:\.\d+)?)\s*(k|lakh|lac|crore|cr)?`)
+)
+
+// ParseCompensation extracts a normalized Compensation from free-text salary,
+// handling currency symbols/codes, k/lakh/crore multipliers, and
+// annum/year/hr/mo/week interval keywords. ok is false if text contains no
+// recognizable amount. A single bound (no range separator) is returned as
+// MinAmount == MaxAmount.
+func ParseCompensation(text string) (Compensation, bool) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return Compensation{}, false
+	}
+
+	lower := strings.ToLower(trimmed)
+	currency := detectCompensationCurrency(trimmed)
+	interval := detectCompensationInterval(lower)
+
+	// "LPA" (lakhs per annum) states its multiplier once, for both bounds,
+	// rather than suffixing each number individually - e.g. "INR 6-9 LPA"
+	// means 6-9 lakhs, not 6-9 rupees.
+	defaultMultiplier := 1.0
+	if strings.Contains(lower, "lpa") {
+		defaultMultiplier = 100000
+		if currency == "" {
+			currency = "INR"
+		}
+	}
+
+	if match := compensationRangePattern.FindStringSubmatch(trimmed); match != nil {
+		min, okMin := parseCompensationAmount(match[1], match[2], defaultMultiplier)
+		max, okMax := parseCompensationAmount(match[3], match[4], defaultMultiplier)
+		if okMin && okMax {
+			if min > max {
+				min, max = max, min
+			}
+			return Compensation{MinAmount: min, MaxAmount: max, Currency: currency, Interval: interval, Raw: trimmed}, true
+		}
+	}
+
+	if match := compensationSinglePattern.FindStringSubmatch(trimmed); match != nil && match[1] != "" {
+		amount, ok := parseCompensationAmount(match[1], match[2], defaultMultiplier)
+		if ok {
+			return Compensation{MinAmount: amount, MaxAmount: amount, Currency: currency, Interval: interval, Raw: trimmed}, true
+		}
+	}
+
+	return Compensation{}, false
+}
+
+func parseCompensationAmount(raw, unit string, defaultMultiplier float64) (float64, bool) {
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(raw, ",", ""), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	multiplier := defaultMultiplier
+	if unit != "" {
+		multiplier = compensationMultiplier(unit)
+	}
+	return amount * multiplier, true
+}
+
+func compensationMultiplier(unit string) float64 {
+	switch strings.ToLower(unit) {
+	case "k":
+		return 1000
+	case "lakh", "lac":
+		return 100000
+	case "crore", "cr":
+		return 10000000
+	default:
+		return 1
+	}
+}
+
+func detectCompensationCurrency(text string) string {
+	for symbol, code := range currencySymbols {
+		if strings.Contains(text, symbol) {
+			return code
+		}
+	}
+	return currencyCodePattern.FindString(strings.ToUpper(text))
+}
+
+func detectCompensationInterval(lower string) CompensationInterval {
+	switch {
+	case strings.Contains(lower, "lpa"), strings.Contains(lower, "annum"), strings.Contains(lower, "annual"),
+		strings.Contains(lower, "/yr"), strings.Contains(lower, "yearly"), strings.Contains(lower, "year"):
+		return IntervalYearly
+	case strings.Contains(lower, "/hr"), strings.Contains(lower, "/hour"), strings.Contains(lower, "hourly"), strings.Contains(lower, "per hour"):
+		return IntervalHourly
+	case strings.Contains(lower, "/mo"), strings.Contains(lower, "/month"), strings.Contains(lower, "monthly"), strings.Contains(lower, "per month"):
+		return IntervalMonthly
+	case strings.Contains(lower, "/week"), strings.Contains(lower, "weekly"), strings.Contains(lower, "per week"):
+		return IntervalWeekly
+	case strings.Contains(lower, "/day"), strings.Contains(lower, "daily"), strings.Contains(lower, "per day"):
+		return IntervalDaily
+	default:
+		return IntervalYearly
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestParseCompensation(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantMin  float64
+		wantMax  float64
+		wantCur  string
+		wantIntv CompensationInterval
+	}{
+		{"remoteok range", "$70k - $90k", 70000, 90000, "USD", IntervalYearly},
+		{"weworkremotely range", "$45,000 - $65,000", 45000, 65000, "USD", IntervalYearly},
+		{"glassdoor gbp per annum", "£30k-£40k per annum", 30000, 40000, "GBP", IntervalYearly},
+		{"indeed india lpa", "INR 6-9 LPA", 600000, 900000, "INR", IntervalYearly},
+		{"eur hourly single bound", "€25/hour", 25, 25, "EUR", IntervalHourly},
+		{"usd annually single bound", "USD 120000 annually", 120000, 120000, "USD", IntervalYearly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseCompensation(tt.text)
+			if !ok {
+				t.Fatalf("ParseCompensation(%q) returned ok=false", tt.text)
+			}
+			if got.MinAmount != tt.wantMin || got.MaxAmount != tt.wantMax {
+				t.Errorf("ParseCompensation(%q) = {Min:%v Max:%v}, want {Min:%v Max:%v}",
+					tt.text, got.MinAmount, got.MaxAmount, tt.wantMin, tt.wantMax)
+			}
+			if got.Currency != tt.wantCur {
+				t.Errorf("ParseCompensation(%q).Currency = %q, want %q", tt.text, got.Currency, tt.wantCur)
+			}
+			if got.Interval != tt.wantIntv {
+				t.Errorf("ParseCompensation(%q).Interval = %q, want %q", tt.text, got.Interval, tt.wantIntv)
+			}
+		})
+	}
+}
+
+func TestParseCompensationEmpty(t *testing.T) {
+	if _, ok := ParseCompensation(""); ok {
+		t.Error("ParseCompensation(\"\") should return ok=false")
+	}
+	if _, ok := ParseCompensation("Competitive salary"); ok {
+		t.Error(`ParseCompensation("Competitive salary") should return ok=false`)
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// proxiesEnvVar lets a deployment supply proxies without a CLI flag, e.g. in
+// a container or cron job where flags are awkward to thread through.
+const proxiesEnvVar = "JOBSCRAPER_PROXIES"
+
+const (
+	proxyMaxRetries     = 3
+	proxyCooldown       = 2 * time.Minute
+	proxyRetryBaseDelay = 500 * time.Millisecond
+)
+
+// resolveProxies returns the proxies named by the -proxies flag, falling
+// back to the comma-separated $JOBSCRAPER_PROXIES if the flag was empty.
+func resolveProxies(flagValue string) []string {
+	raw := flagValue
+	if raw == "" {
+		raw = os.Getenv(proxiesEnvVar)
+	}
+	if raw == "" {
+		return nil
+	}
+
+	var proxies []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
+
+// ProxyStats is a point-in-time snapshot of one proxy's health, exposed so an
+// operator can tell a dead/blocked proxy from a merely slow one.
+type ProxyStats struct {
+	URL        string
+	Successes  int64
+	Failures   int64
+	AvgLatency time.Duration
+	LastError  string
+}
+
+// proxyEntry tracks one proxy's rolling health behind its own mutex, since
+// Next and ReportResult are both called concurrently from every in-flight
+// request.
+type proxyEntry struct {
+	mu            sync.Mutex
+	url           string
+	successes     int64
+	failures      int64
+	totalLatency  time.Duration
+	lastError     string
+	cooldownUntil time.Time
+}
+
+// ProxyPool hands out a proxy URL per outbound request - round-robin among
+// whichever proxies aren't currently cooling down - and records each
+// request's outcome so a proxy that starts getting blocked falls out of
+// rotation instead of being retried forever.
+type ProxyPool struct {
+	mu      sync.Mutex
+	entries []*proxyEntry
+	next    int
+}
+
+// NewProxyPool builds a pool over proxies (http(s):// or socks5://, optionally
+// with embedded user:pass@ credentials); an empty list makes every Next call
+// return "" so direct (proxy-less) requests are made.
+func NewProxyPool(proxies []string) *ProxyPool {
+	pool := &ProxyPool{}
+	for _, p := range proxies {
+		pool.entries = append(pool.entries, &proxyEntry{url: p})
+	}
+	return pool
+}
+
+// Next returns the next proxy in rotation that isn't cooling down, or "" if
+// the pool is empty or every proxy is currently cooled down.
+func (p *ProxyPool) Next() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.entries) == 0 {
+		return ""
+	}
+
+	now := time.Now()
+	for i := 0; i < len(p.entries); i++ {
+		entry := p.entries[p.next]
+		p.next = (p.next + 1) % len(p.entries)
+
+		entry.mu.Lock()
+		cooling := now.Before(entry.cooldownUntil)
+		entry.mu.Unlock()
+		if !cooling {
+			return entry.url
+		}
+	}
+	return ""
+}
+
+// ReportResult records an outbound request's outcome for proxyURL. A 403,
+// 407 (proxy auth required), 429, any 5xx, or a non-nil err counts as a
+// failure and cools the proxy down for proxyCooldown; anything else is a
+// success.
+func (p *ProxyPool) ReportResult(proxyURL string, statusCode int, latency time.Duration, err error) {
+	if proxyURL == "" {
+		return
+	}
+
+	entry := p.entryFor(proxyURL)
+	if entry == nil {
+		return
+	}
+
+	failed := err != nil ||
+		statusCode == http.StatusForbidden ||
+		statusCode == http.StatusProxyAuthRequired ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= 500
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	entry.totalLatency += latency
+	if failed {
+		entry.failures++
+		entry.cooldownUntil = time.Now().Add(proxyCooldown)
+		if err != nil {
+			entry.lastError = err.Error()
+		} else {
+			entry.lastError = fmt.Sprintf("HTTP %d", statusCode)
+		}
+		return
+	}
+	entry.successes++
+}
+
+func (p *ProxyPool) entryFor(proxyURL string) *proxyEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, entry := range p.entries {
+		if entry.url == proxyURL {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Stats returns a snapshot of every proxy's health, in rotation order.
+func (p *ProxyPool) Stats() []ProxyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]ProxyStats, 0, len(p.entries))
+	for _, entry := range p.entries {
+		entry.mu.Lock()
+		total := entry.successes + entry.failures
+		var avg time.Duration
+		if total > 0 {
+			avg = entry.totalLatency / time.Duration(total)
+		}
+		stats = append(stats, ProxyStats{
+			URL:        entry.url,
+			Successes:  entry.successes,
+			Failures:   entry.failures,
+			AvgLatency: avg,
+			LastError:  entry.lastError,
+		})
+		entry.mu.Unlock()
+	}
+	return stats
+}
+
+// proxyTransport wraps pool's rotation and proxyMaxRetries of retry (with
+// exponential backoff + jitter) around a plain http.RoundTripper, so every
+// request js.client makes rotates proxy and User-Agent per attempt. Colly
+// collectors get only the proxy rotation, via applyProxyPool below.
+type proxyTransport struct {
+	pool       *ProxyPool
+	userAgents []string
+}
+
+func (t *proxyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= proxyMaxRetries; attempt++ {
+		proxyURL := t.pool.Next()
+		transport, err := transportForProxy(proxyURL)
+		if err != nil {
+			return nil, err
+		}
+
+		attemptReq := req.Clone(req.Context())
+		if len(t.userAgents) > 0 {
+			attemptReq.Header.Set("User-Agent", t.userAgents[rand.Intn(len(t.userAgents))])
+		}
+
+		start := time.Now()
+		resp, err := transport.RoundTrip(attemptReq)
+		latency := time.Since(start)
+
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		t.pool.ReportResult(proxyURL, statusCode, latency, err)
+
+		retryable := err != nil || statusCode == http.StatusForbidden ||
+			statusCode == http.StatusProxyAuthRequired ||
+			statusCode == http.StatusTooManyRequests ||
+			statusCode >= 500
+		if !retryable {
+			return resp, err
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+		lastErr = err
+		if lastErr == nil {
+			lastErr = fmt.Errorf("proxied request failed with status %d", statusCode)
+		}
+
+		if attempt == proxyMaxRetries {
+			break
+		}
+		time.Sleep(proxyBackoff(attempt))
+	}
+	return nil, fmt.Errorf("all proxy attempts failed: %w", lastErr)
+}
+
+// proxyBackoff doubles proxyRetryBaseDelay per attempt and adds up to 50%
+// jitter so a burst of retrying requests doesn't all hammer the next proxy
+// in lockstep.
+func proxyBackoff(attempt int) time.Duration {
+	base := proxyRetryBaseDelay * time.Duration(1<<uint(attempt))
+	jitter := time.Duration(rand.Int63n(int64(base) / 2))
+	return base + jitter
+}
+
+// transportForProxy builds an *http.Transport routed through proxyURL.
+// net/http's Transport.Proxy natively dials through http, https, and socks5
+// proxy URLs (including embedded user:pass@ credentials), so no extra
+// library is needed for authenticated or SOCKS5 proxies.
+func transportForProxy(proxyURL string) (*http.Transport, error) {
+	t := &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if proxyURL == "" {
+		return t, nil
+	}
+
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+	}
+	t.Proxy = http.ProxyURL(parsed)
+	return t, nil
+}
+
+// useProxyPool wires pool into js: the shared http.Client is given a
+// retrying, proxy-rotating Transport, and every colly collector this scraper
+// creates routes through the same pool via SetProxyFunc.
+func (js *JobScraper) useProxyPool(pool *ProxyPool) {
+	js.proxyPool = pool
+	js.client.Transport = &proxyTransport{pool: pool, userAgents: js.userAgents}
+}
+
+// applyProxyPool wires c's requests through js.proxyPool: each request takes
+// the pool's next proxy and its outcome is reported back to the pool. Unlike
+// proxyTransport, it does not retry failed requests or change c's
+// User-Agent. A nil or empty proxyPool leaves c making direct requests.
+func (js *JobScraper) applyProxyPool(c *colly.Collector) {
+	if js.proxyPool == nil {
+		return
+	}
+
+	c.SetProxyFunc(func(r *http.Request) (*url.URL, error) {
+		proxyURL := js.proxyPool.Next()
+		if proxyURL == "" {
+			return nil, nil
+		}
+		return url.Parse(proxyURL)
+	})
+
+	c.OnResponse(func(r *colly.Response) {
+		proxyURL := r.Request.ProxyURL
+		js.proxyPool.ReportResult(proxyURL, r.StatusCode, 0, nil)
+	})
+	c.OnError(func(r *colly.Response, err error) {
+		proxyURL := r.Request.ProxyURL
+		js.proxyPool.ReportResult(proxyURL, r.StatusCode, 0, err)
+	})
+}
@@ -0,0 +1,62 @@
+package main
+
+// Indeed: plain HTML search results, paginated via a `start` offset.
+const (
+	indeedBaseURL        = "https://www.indeed.com/jobs"
+	indeedResultsPerPage = 10
+	indeedMaxPages       = 3
+
+	indeedJobContainerSelector = ".job_seen_beacon"
+	indeedTitleSelector        = ".jobTitle"
+	indeedCompanySelector      = ".companyName"
+	indeedLocationSelector     = ".companyLocation"
+	indeedSnippetSelector      = ".job-snippet"
+	indeedSalarySelector       = ".salary-snippet"
+	indeedDateSelector         = ".date"
+)
+
+// LinkedIn: the public (unauthenticated) jobs search page, paginated via a
+// `start` offset.
+const (
+	linkedInBaseURL        = "https://www.linkedin.com/jobs/search"
+	linkedInResultsPerPage = 25
+	linkedInMaxPages       = 3
+
+	linkedInJobContainerSelector = ".base-card"
+	linkedInTitleSelector        = ".base-search-card__title"
+	linkedInCompanySelector      = ".base-search-card__subtitle"
+	linkedInLocationSelector     = ".job-search-card__location"
+	linkedInDateSelector         = ".listed-time"
+)
+
+// Glassdoor: the internal GraphQL API behind the search UI, paginated via
+// the paginationCursors each response returns rather than a page number.
+const (
+	glassdoorGraphQLPath    = "/graph"
+	glassdoorLocationsPath  = "/findPopularLocationAjax.htm"
+	glassdoorDefaultCountry = "US"
+	glassdoorNumJobsPerPage = 30
+	glassdoorMaxPages       = 3
+	glassdoorMaxRetries     = 4
+	glassdoorRetryBaseDelay = 500 // milliseconds; doubled on each 429/403 retry
+	glassdoorClientName     = "job-search-next"
+)
+
+// glassdoorBaseURLByCountry maps a country code to Glassdoor's per-TLD base
+// URL; both the locations lookup and the GraphQL query go through it.
+var glassdoorBaseURLByCountry = map[string]string{
+	"US": "https://www.glassdoor.com",
+	"UK": "https://www.glassdoor.co.uk",
+	"CA": "https://www.glassdoor.ca",
+	"AU": "https://www.glassdoor.com.au",
+	"DE": "https://www.glassdoor.de",
+	"FR": "https://www.glassdoor.fr",
+	"IN": "https://www.glassdoor.co.in",
+}
+
+// ZipRecruiter: like Glassdoor, this goes through an API (its JSON
+// jobs-search endpoint) rather than scraping rendered HTML.
+const (
+	zipRecruiterAPIURL = "https://www.ziprecruiter.com/jobs-search/json"
+	zipRecruiterPages  = 3
+)
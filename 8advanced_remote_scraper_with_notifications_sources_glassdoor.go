@@ -0,0 +1,342 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// GlassdoorSource queries Glassdoor's internal GraphQL search API directly,
+// the same endpoint the site's own search UI calls, rather than parsing
+// rendered HTML - Glassdoor's markup is obfuscated and changes often enough
+// that a CSS-selector scraper goes stale fast.
+type GlassdoorSource struct {
+	rateLimiter *rate.Limiter
+	userAgents  []string
+	client      *http.Client
+	country     string
+}
+
+func newGlassdoorSource(rateLimiter *rate.Limiter, userAgents []string, client *http.Client) *GlassdoorSource {
+	return &GlassdoorSource{rateLimiter: rateLimiter, userAgents: userAgents, client: client, country: glassdoorDefaultCountry}
+}
+
+func (s *GlassdoorSource) Name() string { return "Glassdoor" }
+
+var glassdoorCSRFTokenPattern = regexp.MustCompile(`"token"\s*:\s*"([^"]+)"`)
+
+// fetchCSRFToken loads the search page once to pull the gd-csrf-token every
+// GraphQL call must carry.
+func (s *GlassdoorSource) fetchCSRFToken(ctx context.Context, baseURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/Job/jobs.htm", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", s.userAgents[0])
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if match := glassdoorCSRFTokenPattern.FindSubmatch(body); match != nil {
+		return string(match[1]), nil
+	}
+	return "", fmt.Errorf("could not find csrf token on %s", baseURL)
+}
+
+type glassdoorLocation struct {
+	LocationID   int    `json:"locationId"`
+	LocationType string `json:"locationType"`
+	LongName     string `json:"longName"`
+}
+
+// resolveLocation turns a free-text location into the locationId/locationType
+// pair the jobListings query requires, via Glassdoor's own typeahead endpoint.
+func (s *GlassdoorSource) resolveLocation(ctx context.Context, baseURL, location string) (int, string, error) {
+	target := fmt.Sprintf("%s%s?term=%s", baseURL, glassdoorLocationsPath, url.QueryEscape(location))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("User-Agent", s.userAgents[0])
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	var locations []glassdoorLocation
+	if err := json.NewDecoder(resp.Body).Decode(&locations); err != nil {
+		return 0, "", fmt.Errorf("decode glassdoor locations: %w", err)
+	}
+	if len(locations) == 0 {
+		return 0, "", fmt.Errorf("no glassdoor location match for %q", location)
+	}
+	return locations[0].LocationID, locations[0].LocationType, nil
+}
+
+// doGraphQL posts payload to baseURL's /graph endpoint, retrying with
+// exponential backoff on 429 (rate limited) or 403 (bot-detected) - both are
+// common and transient enough against Glassdoor to be worth a few retries
+// rather than failing the whole query outright.
+func (s *GlassdoorSource) doGraphQL(ctx context.Context, baseURL, csrfToken string, payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := glassdoorRetryBaseDelay * time.Millisecond
+	var lastErr error
+	for attempt := 0; attempt <= glassdoorMaxRetries; attempt++ {
+		if err := s.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		body, retryable, err := s.postGraphQLOnce(ctx, baseURL, csrfToken, data, attempt)
+		if err == nil {
+			return body, nil
+		}
+		if !retryable {
+			return nil, err
+		}
+		lastErr = err
+
+		if attempt == glassdoorMaxRetries {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return nil, fmt.Errorf("glassdoor graphql failed after %d retries: %w", glassdoorMaxRetries, lastErr)
+}
+
+func (s *GlassdoorSource) postGraphQLOnce(ctx context.Context, baseURL, csrfToken string, data []byte, attempt int) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+glassdoorGraphQLPath, bytes.NewReader(data))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("gd-csrf-token", csrfToken)
+	req.Header.Set("apollographql-client-name", glassdoorClientName)
+	req.Header.Set("User-Agent", s.userAgents[attempt%len(s.userAgents)])
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusForbidden:
+		return nil, true, fmt.Errorf("glassdoor returned status %d", resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, false, fmt.Errorf("glassdoor returned status %d", resp.StatusCode)
+	}
+	return body, false, nil
+}
+
+const glassdoorJobListingsQuery = `query JobSearchResultsQuery($filterParams: [FilterParams], $numJobsToShow: Int!, $locationId: Int!, $locationType: LocationTypeEnum, $pageCursor: String, $pageNumber: Int) {
+  jobListings(
+    contextHolder: {
+      searchParams: {
+        filterParams: $filterParams
+        numJobsToShow: $numJobsToShow
+        locationId: $locationId
+        locationType: $locationType
+        pageCursor: $pageCursor
+        pageNumber: $pageNumber
+      }
+    }
+  ) {
+    jobListings {
+      jobview {
+        title
+        employer { name }
+        header {
+          locationName
+          payPeriodAdjustedPay { p10 p90 }
+        }
+        jobLink
+        listingDate
+      }
+    }
+    paginationCursors {
+      cursor
+      pageNumber
+    }
+  }
+}`
+
+type glassdoorPayRange struct {
+	P10 float64 `json:"p10"`
+	P90 float64 `json:"p90"`
+}
+
+type glassdoorJobView struct {
+	Title    string `json:"title"`
+	Employer struct {
+		Name string `json:"name"`
+	} `json:"employer"`
+	Header struct {
+		LocationName         string             `json:"locationName"`
+		PayPeriodAdjustedPay *glassdoorPayRange `json:"payPeriodAdjustedPay"`
+	} `json:"header"`
+	JobLink     string `json:"jobLink"`
+	ListingDate string `json:"listingDate"`
+}
+
+type glassdoorGraphQLResponse []struct {
+	Data struct {
+		JobListings struct {
+			JobListings []struct {
+				JobView glassdoorJobView `json:"jobview"`
+			} `json:"jobListings"`
+			PaginationCursors []struct {
+				Cursor     string `json:"cursor"`
+				PageNumber int    `json:"pageNumber"`
+			} `json:"paginationCursors"`
+		} `json:"jobListings"`
+	} `json:"data"`
+}
+
+func glassdoorSalaryText(pay *glassdoorPayRange) string {
+	if pay == nil || (pay.P10 == 0 && pay.P90 == 0) {
+		return ""
+	}
+	return fmt.Sprintf("$%.0f - $%.0f", pay.P10, pay.P90)
+}
+
+func (s *GlassdoorSource) Search(ctx context.Context, query Query) (<-chan RemoteJob, error) {
+	out := make(chan RemoteJob)
+
+	go func() {
+		defer close(out)
+
+		country := s.country
+		if country == "" {
+			country = glassdoorDefaultCountry
+		}
+		baseURL, ok := glassdoorBaseURLByCountry[country]
+		if !ok {
+			log.Printf("Glassdoor: no base URL configured for country %q", country)
+			return
+		}
+
+		csrfToken, err := s.fetchCSRFToken(ctx, baseURL)
+		if err != nil {
+			log.Printf("Glassdoor: failed to fetch CSRF token: %v", err)
+			return
+		}
+
+		locationID, locationType, err := s.resolveLocation(ctx, baseURL, query.Location)
+		if err != nil {
+			log.Printf("Glassdoor: failed to resolve location %q: %v", query.Location, err)
+			return
+		}
+
+		for _, title := range query.Titles {
+			filterParams := []map[string]string{{"filterKey": "jobTitle", "values": title}}
+			if query.PostedWithin > 0 {
+				days := int(query.PostedWithin / (24 * time.Hour))
+				if days < 1 {
+					days = 1
+				}
+				filterParams = append(filterParams, map[string]string{"filterKey": "fromAge", "values": fmt.Sprintf("%d", days)})
+			}
+
+			cursor := ""
+			for page := 1; page <= glassdoorMaxPages; page++ {
+				payload := []map[string]interface{}{
+					{
+						"operationName": "JobSearchResultsQuery",
+						"query":         glassdoorJobListingsQuery,
+						"variables": map[string]interface{}{
+							"filterParams":  filterParams,
+							"numJobsToShow": glassdoorNumJobsPerPage,
+							"locationId":    locationID,
+							"locationType":  locationType,
+							"pageCursor":    cursor,
+							"pageNumber":    page,
+						},
+					},
+				}
+
+				body, err := s.doGraphQL(ctx, baseURL, csrfToken, payload)
+				if err != nil {
+					log.Printf("Glassdoor: query failed for %q page %d: %v", title, page, err)
+					break
+				}
+
+				var parsed glassdoorGraphQLResponse
+				if err := json.Unmarshal(body, &parsed); err != nil {
+					log.Printf("Glassdoor: failed to decode response for %q: %v", title, err)
+					break
+				}
+				if len(parsed) == 0 {
+					break
+				}
+
+				listings := parsed[0].Data.JobListings
+				for _, item := range listings.JobListings {
+					jv := item.JobView
+					job := RemoteJob{
+						Title:      jv.Title,
+						Company:    jv.Employer.Name,
+						Location:   jv.Header.LocationName,
+						Salary:     glassdoorSalaryText(jv.Header.PayPeriodAdjustedPay),
+						URL:        jv.JobLink,
+						PostedDate: jv.ListingDate,
+					}
+					if job.Title == "" || job.Company == "" {
+						continue
+					}
+					select {
+					case out <- job:
+					case <-ctx.Done():
+						return
+					}
+				}
+
+				nextCursor := ""
+				for _, pc := range listings.PaginationCursors {
+					if pc.PageNumber == page+1 {
+						nextCursor = pc.Cursor
+						break
+					}
+				}
+				if nextCursor == "" {
+					break
+				}
+				cursor = nextCursor
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/url"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+	"golang.org/x/time/rate"
+)
+
+// indeedJobTypeCodes maps Query.JobType to Indeed's jt= URL parameter.
+var indeedJobTypeCodes = map[string]string{
+	"fulltime":   "fulltime",
+	"parttime":   "parttime",
+	"contract":   "contract",
+	"internship": "internship",
+	"temporary":  "temporary",
+}
+
+// IndeedSource scrapes Indeed's public job search results.
+type IndeedSource struct {
+	rateLimiter *rate.Limiter
+	userAgents  []string
+}
+
+func newIndeedSource(rateLimiter *rate.Limiter, userAgents []string) *IndeedSource {
+	return &IndeedSource{rateLimiter: rateLimiter, userAgents: userAgents}
+}
+
+func (s *IndeedSource) Name() string { return "Indeed" }
+
+// Search pages through Indeed's results for every title in query.Titles,
+// streaming each listing it finds until indeedMaxPages is exhausted or ctx
+// is cancelled.
+func (s *IndeedSource) Search(ctx context.Context, query Query) (<-chan RemoteJob, error) {
+	out := make(chan RemoteJob)
+
+	go func() {
+		defer close(out)
+
+		for _, title := range query.Titles {
+			for page := 0; page < indeedMaxPages; page++ {
+				if err := s.rateLimiter.Wait(ctx); err != nil {
+					return
+				}
+
+				c := colly.NewCollector()
+				c.UserAgent = s.userAgents[rand.Intn(len(s.userAgents))]
+
+				c.OnHTML(indeedJobContainerSelector, func(e *colly.HTMLElement) {
+					job := RemoteJob{
+						Title:       e.ChildText(indeedTitleSelector),
+						Company:     e.ChildText(indeedCompanySelector),
+						Location:    e.ChildText(indeedLocationSelector),
+						Description: e.ChildText(indeedSnippetSelector),
+						Salary:      e.ChildText(indeedSalarySelector),
+						PostedDate:  e.ChildText(indeedDateSelector),
+						URL:         e.Request.URL.String(),
+					}
+					if job.Title == "" || job.Company == "" {
+						return
+					}
+					select {
+					case out <- job:
+					case <-ctx.Done():
+					}
+				})
+
+				searchURL := fmt.Sprintf("%s?q=%s&l=%s&start=%d",
+					indeedBaseURL, url.QueryEscape(title), url.QueryEscape(query.Location), page*indeedResultsPerPage)
+
+				if query.PostedWithin > 0 {
+					days := int(query.PostedWithin / (24 * time.Hour))
+					if days < 1 {
+						days = 1
+					}
+					searchURL += fmt.Sprintf("&fromage=%d&sort=date", days)
+				}
+				if query.Distance > 0 {
+					searchURL += fmt.Sprintf("&radius=%d", query.Distance)
+				}
+				if jt, ok := indeedJobTypeCodes[query.JobType]; ok {
+					searchURL += "&jt=" + jt
+				}
+
+				if err := c.Visit(searchURL); err != nil {
+					log.Printf("Indeed: failed to visit page %d for %q: %v", page, title, err)
+					break
+				}
+				c.Wait()
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/url"
+
+	"github.com/gocolly/colly/v2"
+	"golang.org/x/time/rate"
+)
+
+// linkedInJobTypeCodes maps Query.JobType to LinkedIn's f_JT= URL parameter.
+var linkedInJobTypeCodes = map[string]string{
+	"fulltime":   "F",
+	"parttime":   "P",
+	"contract":   "C",
+	"temporary":  "T",
+	"internship": "I",
+}
+
+// LinkedInSource scrapes LinkedIn's public (unauthenticated) job search
+// results.
+type LinkedInSource struct {
+	rateLimiter *rate.Limiter
+	userAgents  []string
+}
+
+func newLinkedInSource(rateLimiter *rate.Limiter, userAgents []string) *LinkedInSource {
+	return &LinkedInSource{rateLimiter: rateLimiter, userAgents: userAgents}
+}
+
+func (s *LinkedInSource) Name() string { return "LinkedIn" }
+
+func (s *LinkedInSource) Search(ctx context.Context, query Query) (<-chan RemoteJob, error) {
+	out := make(chan RemoteJob)
+
+	go func() {
+		defer close(out)
+
+		for _, title := range query.Titles {
+			for page := 0; page < linkedInMaxPages; page++ {
+				if err := s.rateLimiter.Wait(ctx); err != nil {
+					return
+				}
+
+				c := colly.NewCollector()
+				c.UserAgent = s.userAgents[rand.Intn(len(s.userAgents))]
+
+				c.OnHTML(linkedInJobContainerSelector, func(e *colly.HTMLElement) {
+					job := RemoteJob{
+						Title:      e.ChildText(linkedInTitleSelector),
+						Company:    e.ChildText(linkedInCompanySelector),
+						Location:   e.ChildText(linkedInLocationSelector),
+						PostedDate: e.ChildText(linkedInDateSelector),
+						URL:        e.Request.URL.String(),
+					}
+					if job.Title == "" || job.Company == "" {
+						return
+					}
+					select {
+					case out <- job:
+					case <-ctx.Done():
+					}
+				})
+
+				searchURL := fmt.Sprintf("%s?keywords=%s&location=%s&start=%d",
+					linkedInBaseURL, url.QueryEscape(title), url.QueryEscape(query.Location), page*linkedInResultsPerPage)
+
+				if query.PostedWithin > 0 {
+					searchURL += fmt.Sprintf("&f_TPR=r%d", int(query.PostedWithin.Seconds()))
+				}
+				if jt, ok := linkedInJobTypeCodes[query.JobType]; ok {
+					searchURL += "&f_JT=" + jt
+				}
+
+				if err := c.Visit(searchURL); err != nil {
+					log.Printf("LinkedIn: failed to visit page %d for %q: %v", page, title, err)
+					break
+				}
+				c.Wait()
+			}
+		}
+	}()
+
+	return out, nil
+}
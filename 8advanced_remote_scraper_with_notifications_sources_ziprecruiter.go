@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ZipRecruiterSource queries ZipRecruiter's JSON jobs-search API directly,
+// rather than scraping rendered HTML the way the Indeed and LinkedIn
+// sources do.
+type ZipRecruiterSource struct {
+	rateLimiter *rate.Limiter
+	userAgents  []string
+	client      *http.Client
+}
+
+func newZipRecruiterSource(rateLimiter *rate.Limiter, userAgents []string, client *http.Client) *ZipRecruiterSource {
+	return &ZipRecruiterSource{rateLimiter: rateLimiter, userAgents: userAgents, client: client}
+}
+
+func (s *ZipRecruiterSource) Name() string { return "ZipRecruiter" }
+
+// zipRecruiterAPIResponse is the shape of a ZipRecruiter jobs-search API
+// page: a flat list of jobs plus a cursor for the next page.
+type zipRecruiterAPIResponse struct {
+	Jobs []struct {
+		Name          string `json:"name"`
+		HiringCompany struct {
+			Name string `json:"name"`
+		} `json:"hiring_company"`
+		Location   string `json:"location"`
+		Snippet    string `json:"snippet"`
+		Salary     string `json:"salary_min_formatted"`
+		PostedTime string `json:"posted_time_friendly"`
+		URL        string `json:"url"`
+	} `json:"jobs"`
+	NextPage string `json:"next_page"`
+}
+
+func (s *ZipRecruiterSource) Search(ctx context.Context, query Query) (<-chan RemoteJob, error) {
+	out := make(chan RemoteJob)
+
+	go func() {
+		defer close(out)
+
+		for _, title := range query.Titles {
+			cursor := ""
+			for page := 0; page < zipRecruiterPages; page++ {
+				if err := s.rateLimiter.Wait(ctx); err != nil {
+					return
+				}
+
+				apiURL := fmt.Sprintf("%s?search=%s&location=%s&cursor=%s",
+					zipRecruiterAPIURL, url.QueryEscape(title), url.QueryEscape(query.Location), url.QueryEscape(cursor))
+
+				if query.PostedWithin > 0 {
+					days := int(query.PostedWithin / (24 * time.Hour))
+					if days < 1 {
+						days = 1
+					}
+					apiURL += fmt.Sprintf("&days_ago=%d", days)
+				}
+				if query.Distance > 0 {
+					apiURL += fmt.Sprintf("&radius=%d", query.Distance)
+				}
+
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+				if err != nil {
+					log.Printf("ZipRecruiter: failed to build request for %q: %v", title, err)
+					break
+				}
+				req.Header.Set("User-Agent", s.userAgents[page%len(s.userAgents)])
+
+				resp, err := s.client.Do(req)
+				if err != nil {
+					log.Printf("ZipRecruiter: request failed for %q: %v", title, err)
+					break
+				}
+
+				var parsed zipRecruiterAPIResponse
+				decodeErr := json.NewDecoder(resp.Body).Decode(&parsed)
+				resp.Body.Close()
+				if decodeErr != nil {
+					log.Printf("ZipRecruiter: failed to decode response for %q: %v", title, decodeErr)
+					break
+				}
+
+				for _, j := range parsed.Jobs {
+					job := RemoteJob{
+						Title:       j.Name,
+						Company:     j.HiringCompany.Name,
+						Location:    j.Location,
+						Description: j.Snippet,
+						Salary:      j.Salary,
+						PostedDate:  j.PostedTime,
+						URL:         j.URL,
+					}
+					if job.Title == "" || job.Company == "" {
+						continue
+					}
+					select {
+					case out <- job:
+					case <-ctx.Done():
+						return
+					}
+				}
+
+				if parsed.NextPage == "" {
+					break
+				}
+				cursor = parsed.NextPage
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,574 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// storeDBPath is where JobScraper persists jobs across restarts so the
+// digest only alerts on jobs it hasn't already reported.
+const storeDBPath = "getalljobs.db"
+
+// storeRetentionDays bounds how long a stale listing (one no longer seen by
+// any scrape) stays in the store before PruneOlderThan removes it.
+const storeRetentionDays = 30
+
+// storeDriverEnvVar/storeDSNEnvVar let a deployment point NewJobStore at
+// Postgres instead of the default local SQLite file, without threading a
+// driver/DSN flag through every one of NewJobScraper's callers.
+const (
+	storeDriverEnvVar = "JOBSCRAPER_STORE_DRIVER" // "sqlite" (default) or "postgres"
+	storeDSNEnvVar    = "JOBSCRAPER_STORE_DSN"
+)
+
+// JobStore persists scraped jobs plus the run/subscriber bookkeeping layered
+// on top of them, behind a swappable backend - SQLiteStore by default, or
+// PostgresStore when $JOBSCRAPER_STORE_DRIVER=postgres. Everything that used
+// to reach for the concrete *JobStore now depends on this interface instead.
+type JobStore interface {
+	UpsertJob(ctx context.Context, job RemoteJob) (isNew bool, err error)
+	StartRun(ctx context.Context) (int64, error)
+	FinishRun(ctx context.Context, runID int64, newCount int) error
+	JobsSince(ctx context.Context, since time.Time) ([]RemoteJob, error)
+	AllJobs(ctx context.Context) ([]RemoteJob, error)
+	PruneOlderThan(ctx context.Context, olderThanDays int) (int64, error)
+	ExportCSV(ctx context.Context, filename string) error
+
+	UpsertTelegramSubscriber(ctx context.Context, chatID int64) error
+	SetTelegramFilters(ctx context.Context, chatID int64, filters []string) error
+	SetTelegramExcludes(ctx context.Context, chatID int64, excludes []string) error
+	TelegramSubscribers(ctx context.Context) ([]TelegramSubscriber, error)
+	HideJobForSubscriber(ctx context.Context, chatID int64, jobID string) error
+	IsJobHiddenForSubscriber(ctx context.Context, chatID int64, jobID string) (bool, error)
+
+	AddEmailSubscriber(ctx context.Context, email, phone string, filters []string) (int64, error)
+	ConfirmEmailSubscriber(ctx context.Context, email string) error
+	UnsubscribeEmail(ctx context.Context, email string) error
+	MarkEmailBounced(ctx context.Context, email string) error
+	ConfirmedEmailSubscribers(ctx context.Context) ([]EmailSubscriber, error)
+
+	Close() error
+}
+
+// NewJobStore opens the backend configured by $JOBSCRAPER_STORE_DRIVER: a
+// SQLiteStore at path by default, or a PostgresStore at $JOBSCRAPER_STORE_DSN
+// when the driver is "postgres".
+func NewJobStore(path string) (JobStore, error) {
+	if os.Getenv(storeDriverEnvVar) == "postgres" {
+		dsn := os.Getenv(storeDSNEnvVar)
+		if dsn == "" {
+			return nil, fmt.Errorf("%s=postgres requires %s to be set", storeDriverEnvVar, storeDSNEnvVar)
+		}
+		return NewPostgresStore(dsn)
+	}
+	return NewSQLiteStore(path)
+}
+
+// SQLiteStore implements JobStore over modernc.org/sqlite, so no CGO
+// toolchain is required. It's the default backend.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	id TEXT PRIMARY KEY,
+	platform TEXT,
+	title TEXT,
+	company TEXT,
+	location TEXT,
+	description TEXT,
+	salary_min INTEGER,
+	salary_max INTEGER,
+	salary_currency TEXT,
+	salary_period TEXT,
+	posted_date TEXT,
+	job_type TEXT,
+	experience TEXT,
+	is_remote INTEGER,
+	is_fresher INTEGER,
+	url TEXT,
+	apply_url TEXT,
+	first_seen TEXT,
+	last_seen TEXT,
+	content_hash TEXT
+);
+CREATE TABLE IF NOT EXISTS runs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	started_at TEXT,
+	finished_at TEXT,
+	new_count INTEGER
+);
+CREATE TABLE IF NOT EXISTS telegram_subscribers (
+	chat_id INTEGER PRIMARY KEY,
+	filters TEXT,
+	excludes TEXT,
+	created_at TEXT
+);
+CREATE TABLE IF NOT EXISTS telegram_hidden_jobs (
+	chat_id INTEGER,
+	job_id TEXT,
+	hidden_at TEXT,
+	PRIMARY KEY (chat_id, job_id)
+);
+CREATE TABLE IF NOT EXISTS email_subscribers (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	email TEXT UNIQUE,
+	phone TEXT,
+	filters TEXT,
+	confirmed_at TEXT,
+	bounced INTEGER,
+	unsubscribed INTEGER,
+	created_at TEXT
+);`
+
+// NewSQLiteStore opens (creating if necessary) a SQLiteStore at path.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// parseSalaryRange splits a RemoteJob's free-text Salary into the numeric
+// bounds + currency/period the store keeps queryable, via the same
+// ParseCompensation used to populate RemoteJob.Compensation; jobs whose
+// salary text doesn't parse store as all-zero/empty.
+func parseSalaryRange(salary string) (min, max int, currency, period string) {
+	comp, ok := ParseCompensation(salary)
+	if !ok {
+		return 0, 0, "", ""
+	}
+	return int(comp.MinAmount), int(comp.MaxAmount), comp.Currency, compensationIntervalToPeriod(comp.Interval)
+}
+
+// compensationIntervalToPeriod maps a CompensationInterval onto the single
+// word the store's salary_period column uses.
+func compensationIntervalToPeriod(interval CompensationInterval) string {
+	switch interval {
+	case IntervalHourly:
+		return "hour"
+	case IntervalDaily:
+		return "day"
+	case IntervalWeekly:
+		return "week"
+	case IntervalMonthly:
+		return "month"
+	default:
+		return "year"
+	}
+}
+
+func jobContentHash(job RemoteJob) string {
+	sum := sha256.Sum256([]byte(job.Title + "|" + job.Company + "|" + job.Location + "|" + job.Salary + "|" + job.Description))
+	return fmt.Sprintf("%x", sum)
+}
+
+// UpsertJob inserts job, or refreshes it in place if it already exists. It
+// reports isNew=true both the first time id is ever stored and whenever its
+// content_hash has changed since last time (a genuinely changed posting, not
+// just a re-scrape of the same one), so a restarted scraper doesn't re-alert
+// on jobs that haven't actually changed.
+func (s *SQLiteStore) UpsertJob(ctx context.Context, job RemoteJob) (isNew bool, err error) {
+	now := time.Now().Format(time.RFC3339)
+	hash := jobContentHash(job)
+	min, max, currency, period := parseSalaryRange(job.Salary)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var existingHash string
+	err = tx.QueryRowContext(ctx, `SELECT content_hash FROM jobs WHERE id = ?`, job.ID).Scan(&existingHash)
+	switch {
+	case err == sql.ErrNoRows:
+		isNew = true
+	case err != nil:
+		return false, err
+	default:
+		isNew = existingHash != hash
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO jobs (id, platform, title, company, location, description,
+			salary_min, salary_max, salary_currency, salary_period, posted_date,
+			job_type, experience, is_remote, is_fresher, url, apply_url, first_seen, last_seen, content_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			last_seen=excluded.last_seen, content_hash=excluded.content_hash,
+			salary_min=excluded.salary_min, salary_max=excluded.salary_max,
+			salary_currency=excluded.salary_currency, salary_period=excluded.salary_period,
+			description=excluded.description
+	`, job.ID, job.Platform, job.Title, job.Company, job.Location, job.Description,
+		min, max, currency, period, job.PostedDate,
+		job.JobType, job.Experience, boolToInt(job.IsRemote), boolToInt(job.IsFresher), job.URL, job.ApplyURL,
+		now, now, hash)
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return isNew, nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// StartRun records a new run starting now and returns its id, to be passed
+// to FinishRun once the scrape completes.
+func (s *SQLiteStore) StartRun(ctx context.Context) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `INSERT INTO runs (started_at, new_count) VALUES (?, 0)`, time.Now().Format(time.RFC3339))
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// FinishRun records runID's completion time and final new-job count.
+func (s *SQLiteStore) FinishRun(ctx context.Context, runID int64, newCount int) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE runs SET finished_at = ?, new_count = ? WHERE id = ?`,
+		time.Now().Format(time.RFC3339), newCount, runID)
+	return err
+}
+
+// JobsSince returns every job whose first_seen is at or after since -
+// backing the digest's --since flag and independent of any in-process
+// newJobsCount.
+func (s *SQLiteStore) JobsSince(ctx context.Context, since time.Time) ([]RemoteJob, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, platform, title, company, location, description, salary_min, salary_max,
+			salary_currency, salary_period, posted_date, job_type, experience, is_remote, is_fresher, url, apply_url
+		FROM jobs WHERE first_seen >= ? ORDER BY first_seen ASC`, since.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
+
+// AllJobs returns every stored job, used by ExportCSV and the
+// dump-csv/dump-xlsx commands.
+func (s *SQLiteStore) AllJobs(ctx context.Context) ([]RemoteJob, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, platform, title, company, location, description, salary_min, salary_max,
+			salary_currency, salary_period, posted_date, job_type, experience, is_remote, is_fresher, url, apply_url
+		FROM jobs ORDER BY first_seen ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
+
+func scanJobs(rows *sql.Rows) ([]RemoteJob, error) {
+	var jobs []RemoteJob
+	for rows.Next() {
+		var job RemoteJob
+		var isRemote, isFresher int
+		var salaryMin, salaryMax sql.NullInt64
+		var salaryCurrency, salaryPeriod sql.NullString
+		if err := rows.Scan(&job.ID, &job.Platform, &job.Title, &job.Company, &job.Location, &job.Description,
+			&salaryMin, &salaryMax, &salaryCurrency, &salaryPeriod,
+			&job.PostedDate, &job.JobType, &job.Experience, &isRemote, &isFresher, &job.URL, &job.ApplyURL); err != nil {
+			return nil, err
+		}
+		job.IsRemote = isRemote != 0
+		job.IsFresher = isFresher != 0
+		job.Salary = formatSalaryRange(salaryMin, salaryMax, salaryCurrency, salaryPeriod)
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// formatSalaryRange rebuilds a display string from the decomposed columns,
+// falling back to empty when the original salary text didn't parse into a
+// range (most listings).
+func formatSalaryRange(min, max sql.NullInt64, currency, period sql.NullString) string {
+	if !min.Valid || !max.Valid || (min.Int64 == 0 && max.Int64 == 0) {
+		return ""
+	}
+	unit := currency.String
+	periodText := period.String
+	if periodText == "" {
+		periodText = "year"
+	}
+	return fmt.Sprintf("%s%d - %s%d / %s", unit, min.Int64, unit, max.Int64, periodText)
+}
+
+// PruneOlderThan deletes every job last seen more than olderThanDays days
+// ago and returns how many rows were removed.
+func (s *SQLiteStore) PruneOlderThan(ctx context.Context, olderThanDays int) (int64, error) {
+	cutoff := time.Now().AddDate(0, 0, -olderThanDays).Format(time.RFC3339)
+	res, err := s.db.ExecContext(ctx, `DELETE FROM jobs WHERE last_seen < ?`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+var storeCSVHeader = []string{"ID", "Platform", "Title", "Company", "Location", "Description",
+	"Salary", "PostedDate", "JobType", "Experience", "IsRemote", "IsFresher", "URL"}
+
+// ExportCSV writes every stored job to filename, reading from the database
+// rather than any in-memory slice so it reflects every run, not just this
+// process's.
+func (s *SQLiteStore) ExportCSV(ctx context.Context, filename string) error {
+	jobs, err := s.AllJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read jobs: %w", err)
+	}
+	return writeJobsCSV(filename, jobs)
+}
+
+// writeJobsCSV is shared by SQLiteStore.ExportCSV and the dump-csv command,
+// which both just want jobs on disk regardless of which backend produced them.
+func writeJobsCSV(filename string, jobs []RemoteJob) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create CSV file: %v", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	if err := writer.Write(storeCSVHeader); err != nil {
+		return fmt.Errorf("failed to write headers: %v", err)
+	}
+
+	for _, job := range jobs {
+		record := []string{
+			job.ID, job.Platform, job.Title, job.Company, job.Location, job.Description,
+			job.Salary, job.PostedDate, job.JobType, job.Experience,
+			strconv.FormatBool(job.IsRemote), strconv.FormatBool(job.IsFresher), job.URL,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write job record: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// dumpStoreToFile opens the configured store, reads every job, and hands
+// them to writer - the shared body behind both --dump-csv and --dump-xlsx,
+// which differ only in how they serialize the rows.
+func dumpStoreToFile(path string, writer func(path string, jobs []RemoteJob) error) error {
+	store, err := NewJobStore(storeDBPath)
+	if err != nil {
+		return fmt.Errorf("failed to open job store: %w", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	jobs, err := store.AllJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read jobs: %w", err)
+	}
+
+	if err := writer(path, jobs); err != nil {
+		return err
+	}
+	log.Printf("✅ Exported %d jobs to %s", len(jobs), path)
+	return nil
+}
+
+// TelegramSubscriber is one user's bot registration, with personalized job
+// filters layered on top of the scraper's default keyword lists.
+type TelegramSubscriber struct {
+	ChatID   int64
+	Filters  []string
+	Excludes []string
+}
+
+// UpsertTelegramSubscriber registers chatID if it isn't already known,
+// backing the bot's /start command.
+func (s *SQLiteStore) UpsertTelegramSubscriber(ctx context.Context, chatID int64) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO telegram_subscribers (chat_id, filters, excludes, created_at) VALUES (?, '[]', '[]', ?)
+		ON CONFLICT(chat_id) DO NOTHING
+	`, chatID, time.Now().Format(time.RFC3339))
+	return err
+}
+
+// SetTelegramFilters replaces chatID's required-keyword filters, backing the
+// bot's /filter command.
+func (s *SQLiteStore) SetTelegramFilters(ctx context.Context, chatID int64, filters []string) error {
+	data, err := json.Marshal(filters)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO telegram_subscribers (chat_id, filters, excludes, created_at) VALUES (?, ?, '[]', ?)
+		ON CONFLICT(chat_id) DO UPDATE SET filters=excluded.filters
+	`, chatID, string(data), time.Now().Format(time.RFC3339))
+	return err
+}
+
+// SetTelegramExcludes replaces chatID's exclude keywords, backing the bot's
+// /exclude command.
+func (s *SQLiteStore) SetTelegramExcludes(ctx context.Context, chatID int64, excludes []string) error {
+	data, err := json.Marshal(excludes)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO telegram_subscribers (chat_id, filters, excludes, created_at) VALUES (?, '[]', ?, ?)
+		ON CONFLICT(chat_id) DO UPDATE SET excludes=excluded.excludes
+	`, chatID, string(data), time.Now().Format(time.RFC3339))
+	return err
+}
+
+// TelegramSubscribers returns every registered subscriber, used to match new
+// jobs against each one's personalized filters after a scrape.
+func (s *SQLiteStore) TelegramSubscribers(ctx context.Context) ([]TelegramSubscriber, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT chat_id, filters, excludes FROM telegram_subscribers`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []TelegramSubscriber
+	for rows.Next() {
+		var sub TelegramSubscriber
+		var filtersJSON, excludesJSON string
+		if err := rows.Scan(&sub.ChatID, &filtersJSON, &excludesJSON); err != nil {
+			return nil, err
+		}
+		json.Unmarshal([]byte(filtersJSON), &sub.Filters)
+		json.Unmarshal([]byte(excludesJSON), &sub.Excludes)
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+// HideJobForSubscriber records that chatID hit "Hide" on jobID, so it isn't
+// pushed to them again.
+func (s *SQLiteStore) HideJobForSubscriber(ctx context.Context, chatID int64, jobID string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO telegram_hidden_jobs (chat_id, job_id, hidden_at) VALUES (?, ?, ?)
+		ON CONFLICT(chat_id, job_id) DO NOTHING
+	`, chatID, jobID, time.Now().Format(time.RFC3339))
+	return err
+}
+
+// IsJobHiddenForSubscriber reports whether chatID has already hidden jobID.
+func (s *SQLiteStore) IsJobHiddenForSubscriber(ctx context.Context, chatID int64, jobID string) (bool, error) {
+	var exists int
+	err := s.db.QueryRowContext(ctx, `SELECT 1 FROM telegram_hidden_jobs WHERE chat_id = ? AND job_id = ?`, chatID, jobID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// EmailSubscriber is one double-opt-in email recipient, confirmed via the
+// /confirm endpoint before SendNotifications will ever email them.
+type EmailSubscriber struct {
+	ID           int64
+	Email        string
+	Phone        string
+	Filters      []string
+	ConfirmedAt  string
+	Bounced      bool
+	Unsubscribed bool
+}
+
+// AddEmailSubscriber registers email pending confirmation. Re-registering an
+// existing, not-yet-confirmed email just refreshes its filters.
+func (s *SQLiteStore) AddEmailSubscriber(ctx context.Context, email, phone string, filters []string) (int64, error) {
+	data, err := json.Marshal(filters)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO email_subscribers (email, phone, filters, confirmed_at, bounced, unsubscribed, created_at)
+		VALUES (?, ?, ?, NULL, 0, 0, ?)
+		ON CONFLICT(email) DO UPDATE SET phone=excluded.phone, filters=excluded.filters
+	`, email, phone, string(data), time.Now().Format(time.RFC3339))
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// ConfirmEmailSubscriber marks email as confirmed, backing the /confirm
+// endpoint.
+func (s *SQLiteStore) ConfirmEmailSubscriber(ctx context.Context, email string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE email_subscribers SET confirmed_at = ? WHERE email = ?`,
+		time.Now().Format(time.RFC3339), email)
+	return err
+}
+
+// UnsubscribeEmail flags email so it's skipped by ConfirmedEmailSubscribers,
+// backing the /unsubscribe endpoint.
+func (s *SQLiteStore) UnsubscribeEmail(ctx context.Context, email string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE email_subscribers SET unsubscribed = 1 WHERE email = ?`, email)
+	return err
+}
+
+// MarkEmailBounced flags email as bounced so it's skipped by
+// ConfirmedEmailSubscribers. There's no bounce webhook wired up yet; this
+// exists for whatever eventually calls it (a provider's bounce notification).
+func (s *SQLiteStore) MarkEmailBounced(ctx context.Context, email string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE email_subscribers SET bounced = 1 WHERE email = ?`, email)
+	return err
+}
+
+// ConfirmedEmailSubscribers returns every subscriber who has confirmed and
+// neither bounced nor unsubscribed - the list SendNotifications emails.
+func (s *SQLiteStore) ConfirmedEmailSubscribers(ctx context.Context) ([]EmailSubscriber, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, email, phone, filters, confirmed_at, bounced, unsubscribed
+		FROM email_subscribers
+		WHERE confirmed_at IS NOT NULL AND bounced = 0 AND unsubscribed = 0`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []EmailSubscriber
+	for rows.Next() {
+		var sub EmailSubscriber
+		var filtersJSON string
+		var bounced, unsubscribed int
+		if err := rows.Scan(&sub.ID, &sub.Email, &sub.Phone, &filtersJSON, &sub.ConfirmedAt, &bounced, &unsubscribed); err != nil {
+			return nil, err
+		}
+		json.Unmarshal([]byte(filtersJSON), &sub.Filters)
+		sub.Bounced = bounced != 0
+		sub.Unsubscribed = unsubscribed != 0
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// PostgresStore implements JobStore over a shared Postgres database, for
+// deployments running more than one scraper instance (or that already run
+// Postgres and would rather not add a SQLite file to the mix). It's
+// selected via $JOBSCRAPER_STORE_DRIVER=postgres; see NewJobStore.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+const postgresSchema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	id TEXT PRIMARY KEY,
+	platform TEXT,
+	title TEXT,
+	company TEXT,
+	location TEXT,
+	description TEXT,
+	salary_min INTEGER,
+	salary_max INTEGER,
+	salary_currency TEXT,
+	salary_period TEXT,
+	posted_date TEXT,
+	job_type TEXT,
+	experience TEXT,
+	is_remote BOOLEAN,
+	is_fresher BOOLEAN,
+	url TEXT,
+	apply_url TEXT,
+	first_seen TIMESTAMPTZ,
+	last_seen TIMESTAMPTZ,
+	content_hash TEXT
+);
+CREATE TABLE IF NOT EXISTS runs (
+	id SERIAL PRIMARY KEY,
+	started_at TIMESTAMPTZ,
+	finished_at TIMESTAMPTZ,
+	new_count INTEGER
+);
+CREATE TABLE IF NOT EXISTS telegram_subscribers (
+	chat_id BIGINT PRIMARY KEY,
+	filters TEXT,
+	excludes TEXT,
+	created_at TIMESTAMPTZ
+);
+CREATE TABLE IF NOT EXISTS telegram_hidden_jobs (
+	chat_id BIGINT,
+	job_id TEXT,
+	hidden_at TIMESTAMPTZ,
+	PRIMARY KEY (chat_id, job_id)
+);
+CREATE TABLE IF NOT EXISTS email_subscribers (
+	id SERIAL PRIMARY KEY,
+	email TEXT UNIQUE,
+	phone TEXT,
+	filters TEXT,
+	confirmed_at TIMESTAMPTZ,
+	bounced BOOLEAN,
+	unsubscribed BOOLEAN,
+	created_at TIMESTAMPTZ
+);`
+
+// NewPostgresStore opens dsn (a postgres:// connection string) and applies
+// the schema above if it isn't already present.
+func NewPostgresStore(dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(postgresSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &PostgresStore{db: db}, nil
+}
+
+func (s *PostgresStore) UpsertJob(ctx context.Context, job RemoteJob) (isNew bool, err error) {
+	now := time.Now()
+	hash := jobContentHash(job)
+	min, max, currency, period := parseSalaryRange(job.Salary)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var existingHash string
+	err = tx.QueryRowContext(ctx, `SELECT content_hash FROM jobs WHERE id = $1`, job.ID).Scan(&existingHash)
+	switch {
+	case err == sql.ErrNoRows:
+		isNew = true
+	case err != nil:
+		return false, err
+	default:
+		isNew = existingHash != hash
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO jobs (id, platform, title, company, location, description,
+			salary_min, salary_max, salary_currency, salary_period, posted_date,
+			job_type, experience, is_remote, is_fresher, url, apply_url, first_seen, last_seen, content_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		ON CONFLICT (id) DO UPDATE SET
+			last_seen=excluded.last_seen, content_hash=excluded.content_hash,
+			salary_min=excluded.salary_min, salary_max=excluded.salary_max,
+			salary_currency=excluded.salary_currency, salary_period=excluded.salary_period,
+			description=excluded.description
+	`, job.ID, job.Platform, job.Title, job.Company, job.Location, job.Description,
+		min, max, currency, period, job.PostedDate,
+		job.JobType, job.Experience, job.IsRemote, job.IsFresher, job.URL, job.ApplyURL,
+		now, now, hash)
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return isNew, nil
+}
+
+func (s *PostgresStore) StartRun(ctx context.Context) (int64, error) {
+	var id int64
+	err := s.db.QueryRowContext(ctx, `INSERT INTO runs (started_at, new_count) VALUES ($1, 0) RETURNING id`, time.Now()).Scan(&id)
+	return id, err
+}
+
+func (s *PostgresStore) FinishRun(ctx context.Context, runID int64, newCount int) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE runs SET finished_at = $1, new_count = $2 WHERE id = $3`,
+		time.Now(), newCount, runID)
+	return err
+}
+
+func (s *PostgresStore) JobsSince(ctx context.Context, since time.Time) ([]RemoteJob, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, platform, title, company, location, description, salary_min, salary_max,
+			salary_currency, salary_period, posted_date, job_type, experience, is_remote, is_fresher, url, apply_url
+		FROM jobs WHERE first_seen >= $1 ORDER BY first_seen ASC`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanJobs(rows)
+}
+
+func (s *PostgresStore) AllJobs(ctx context.Context) ([]RemoteJob, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, platform, title, company, location, description, salary_min, salary_max,
+			salary_currency, salary_period, posted_date, job_type, experience, is_remote, is_fresher, url, apply_url
+		FROM jobs ORDER BY first_seen ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanJobs(rows)
+}
+
+func (s *PostgresStore) PruneOlderThan(ctx context.Context, olderThanDays int) (int64, error) {
+	cutoff := time.Now().AddDate(0, 0, -olderThanDays)
+	res, err := s.db.ExecContext(ctx, `DELETE FROM jobs WHERE last_seen < $1`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (s *PostgresStore) ExportCSV(ctx context.Context, filename string) error {
+	jobs, err := s.AllJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read jobs: %w", err)
+	}
+	return writeJobsCSV(filename, jobs)
+}
+
+func (s *PostgresStore) UpsertTelegramSubscriber(ctx context.Context, chatID int64) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO telegram_subscribers (chat_id, filters, excludes, created_at) VALUES ($1, '[]', '[]', $2)
+		ON CONFLICT (chat_id) DO NOTHING
+	`, chatID, time.Now())
+	return err
+}
+
+func (s *PostgresStore) SetTelegramFilters(ctx context.Context, chatID int64, filters []string) error {
+	data, err := json.Marshal(filters)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO telegram_subscribers (chat_id, filters, excludes, created_at) VALUES ($1, $2, '[]', $3)
+		ON CONFLICT (chat_id) DO UPDATE SET filters=excluded.filters
+	`, chatID, string(data), time.Now())
+	return err
+}
+
+func (s *PostgresStore) SetTelegramExcludes(ctx context.Context, chatID int64, excludes []string) error {
+	data, err := json.Marshal(excludes)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO telegram_subscribers (chat_id, filters, excludes, created_at) VALUES ($1, '[]', $2, $3)
+		ON CONFLICT (chat_id) DO UPDATE SET excludes=excluded.excludes
+	`, chatID, string(data), time.Now())
+	return err
+}
+
+func (s *PostgresStore) TelegramSubscribers(ctx context.Context) ([]TelegramSubscriber, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT chat_id, filters, excludes FROM telegram_subscribers`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []TelegramSubscriber
+	for rows.Next() {
+		var sub TelegramSubscriber
+		var filtersJSON, excludesJSON string
+		if err := rows.Scan(&sub.ChatID, &filtersJSON, &excludesJSON); err != nil {
+			return nil, err
+		}
+		json.Unmarshal([]byte(filtersJSON), &sub.Filters)
+		json.Unmarshal([]byte(excludesJSON), &sub.Excludes)
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+func (s *PostgresStore) HideJobForSubscriber(ctx context.Context, chatID int64, jobID string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO telegram_hidden_jobs (chat_id, job_id, hidden_at) VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id, job_id) DO NOTHING
+	`, chatID, jobID, time.Now())
+	return err
+}
+
+func (s *PostgresStore) IsJobHiddenForSubscriber(ctx context.Context, chatID int64, jobID string) (bool, error) {
+	var exists int
+	err := s.db.QueryRowContext(ctx, `SELECT 1 FROM telegram_hidden_jobs WHERE chat_id = $1 AND job_id = $2`, chatID, jobID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *PostgresStore) AddEmailSubscriber(ctx context.Context, email, phone string, filters []string) (int64, error) {
+	data, err := json.Marshal(filters)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = s.db.QueryRowContext(ctx, `
+		INSERT INTO email_subscribers (email, phone, filters, confirmed_at, bounced, unsubscribed, created_at)
+		VALUES ($1, $2, $3, NULL, false, false, $4)
+		ON CONFLICT (email) DO UPDATE SET phone=excluded.phone, filters=excluded.filters
+		RETURNING id
+	`, email, phone, string(data), time.Now()).Scan(&id)
+	return id, err
+}
+
+func (s *PostgresStore) ConfirmEmailSubscriber(ctx context.Context, email string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE email_subscribers SET confirmed_at = $1 WHERE email = $2`, time.Now(), email)
+	return err
+}
+
+func (s *PostgresStore) UnsubscribeEmail(ctx context.Context, email string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE email_subscribers SET unsubscribed = true WHERE email = $1`, email)
+	return err
+}
+
+func (s *PostgresStore) MarkEmailBounced(ctx context.Context, email string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE email_subscribers SET bounced = true WHERE email = $1`, email)
+	return err
+}
+
+func (s *PostgresStore) ConfirmedEmailSubscribers(ctx context.Context) ([]EmailSubscriber, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, email, phone, filters, confirmed_at, bounced, unsubscribed
+		FROM email_subscribers
+		WHERE confirmed_at IS NOT NULL AND bounced = false AND unsubscribed = false`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []EmailSubscriber
+	for rows.Next() {
+		var sub EmailSubscriber
+		var filtersJSON string
+		if err := rows.Scan(&sub.ID, &sub.Email, &sub.Phone, &filtersJSON, &sub.ConfirmedAt, &sub.Bounced, &sub.Unsubscribed); err != nil {
+			return nil, err
+		}
+		json.Unmarshal([]byte(filtersJSON), &sub.Filters)
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SubscriberConfig configures double opt-in email onboarding: every new
+// subscriber must click a signed confirmation link (served by --serve)
+// before SendNotifications will ever email them. This is what makes it safe
+// to notify anyone other than the author.
+type SubscriberConfig struct {
+	HMACSecret string `json:"hmac_secret"`
+	BaseURL    string `json:"base_url"`
+}
+
+const (
+	subscriberConfirmTTL     = 48 * time.Hour
+	subscriberUnsubscribeTTL = 365 * 24 * time.Hour
+)
+
+// signSubscriberToken HMAC-signs email+expiry with secret, so /confirm and
+// /unsubscribe can trust a token without a database round trip.
+func signSubscriberToken(secret, email string, expiry time.Time) string {
+	payload := email + "|" + strconv.FormatInt(expiry.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	sig := hex.EncodeToString(mac.Sum(nil))
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + sig
+}
+
+// verifySubscriberToken checks token's signature and expiry, returning the
+// email it was issued for.
+func verifySubscriberToken(secret, token string) (email string, ok bool) {
+	encodedPayload, sig, found := strings.Cut(token, ".")
+	if !found {
+		return "", false
+	}
+
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payloadBytes)
+	expectedSig := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expectedSig), []byte(sig)) {
+		return "", false
+	}
+
+	email, expiryStr, found := strings.Cut(string(payloadBytes), "|")
+	if !found {
+		return "", false
+	}
+	expiryUnix, err := strconv.ParseInt(expiryStr, 10, 64)
+	if err != nil || time.Now().Unix() > expiryUnix {
+		return "", false
+	}
+	return email, true
+}
+
+// Subscribe registers email (with optional phone and title-keyword filters)
+// pending confirmation, and emails it a signed confirm link. It's the
+// store-backed half of the double opt-in flow.
+func (js *JobScraper) Subscribe(ctx context.Context, email, phone string, filters []string) error {
+	if js.store == nil {
+		return fmt.Errorf("subscriber onboarding requires a job store")
+	}
+	if js.notifConfig.Subscribers.HMACSecret == "" {
+		return fmt.Errorf("subscribers.hmac_secret is not configured")
+	}
+
+	if _, err := js.store.AddEmailSubscriber(ctx, email, phone, filters); err != nil {
+		return fmt.Errorf("failed to register subscriber: %w", err)
+	}
+	return sendConfirmationEmail(js.notifConfig, email)
+}
+
+func sendConfirmationEmail(cfg NotificationConfig, email string) error {
+	token := signSubscriberToken(cfg.Subscribers.HMACSecret, email, time.Now().Add(subscriberConfirmTTL))
+	confirmURL := fmt.Sprintf("%s/confirm?token=%s", cfg.Subscribers.BaseURL, token)
+
+	body := fmt.Sprintf("Confirm your subscription to remote fresher job alerts by clicking this link:\n\n%s\n\nThis link expires in 48 hours. If you didn't request this, ignore it.", confirmURL)
+	return sendSubscriberEmail(cfg, email, "Confirm your job alert subscription", body, body)
+}
+
+// sendSubscriberEmail reuses SMTPNotifier's multipart Send rather than
+// reimplementing MIME assembly for a one-off recipient.
+func sendSubscriberEmail(cfg NotificationConfig, to, subject, textBody, htmlBody string) error {
+	notifier := &SMTPNotifier{
+		host: cfg.Email.SMTPHost,
+		port: cfg.Email.SMTPPort,
+		user: cfg.Email.FromEmail,
+		pass: cfg.Email.FromPassword,
+		from: cfg.Email.FromEmail,
+		to:   []string{to},
+	}
+	return notifier.Send(context.Background(), Alert{Subject: subject, TextBody: textBody, HTMLBody: htmlBody})
+}
+
+// sendSubscriberDigests emails every confirmed, non-bounced, non-unsubscribed
+// subscriber a digest filtered to their own keywords, with an unsubscribe
+// link, bypassing the Notifiers list entirely.
+func (js *JobScraper) sendSubscriberDigests(ctx context.Context) {
+	if js.store == nil {
+		return
+	}
+
+	subs, err := js.store.ConfirmedEmailSubscribers(ctx)
+	if err != nil {
+		log.Printf("⚠️ Failed to load confirmed subscribers: %v", err)
+		return
+	}
+	if len(subs) == 0 {
+		return
+	}
+
+	templates, err := loadTemplates(templateOverrideDir)
+	if err != nil {
+		log.Printf("⚠️ Failed to load templates for subscriber digests: %v", err)
+		return
+	}
+
+	newJobs := js.newlyAddedJobs()
+	baseData := js.buildDigestData()
+
+	for _, sub := range subs {
+		jobs := filterJobsByKeywords(newJobs, sub.Filters)
+		if len(jobs) == 0 {
+			continue
+		}
+
+		data := baseData
+		data.NewJobs = jobs
+
+		htmlBody, err := templates.renderDigestHTML(data)
+		if err != nil {
+			log.Printf("⚠️ Failed to render HTML digest for %s: %v", sub.Email, err)
+			continue
+		}
+		textBody, err := templates.renderDigestText(data)
+		if err != nil {
+			log.Printf("⚠️ Failed to render text digest for %s: %v", sub.Email, err)
+			continue
+		}
+
+		if js.notifConfig.Subscribers.HMACSecret != "" {
+			token := signSubscriberToken(js.notifConfig.Subscribers.HMACSecret, sub.Email, time.Now().Add(subscriberUnsubscribeTTL))
+			unsubURL := fmt.Sprintf("%s/unsubscribe?token=%s", js.notifConfig.Subscribers.BaseURL, token)
+			textBody += fmt.Sprintf("\n\nUnsubscribe: %s", unsubURL)
+			htmlBody += fmt.Sprintf(`<p><a href="%s">Unsubscribe</a></p>`, unsubURL)
+		}
+
+		subject := fmt.Sprintf("🎯 %d New Remote Fresher Jobs Found!", len(jobs))
+		if err := sendSubscriberEmail(js.notifConfig, sub.Email, subject, textBody, htmlBody); err != nil {
+			log.Printf("⚠️ Failed to email subscriber %s: %v", sub.Email, err)
+		}
+	}
+}
+
+// filterJobsByKeywords keeps only jobs whose title or description mentions
+// at least one of keywords; an empty keyword list keeps everything.
+func filterJobsByKeywords(jobs []RemoteJob, keywords []string) []RemoteJob {
+	if len(keywords) == 0 {
+		return jobs
+	}
+
+	var matched []RemoteJob
+	for _, job := range jobs {
+		haystack := strings.ToLower(job.Title + " " + job.Description)
+		for _, keyword := range keywords {
+			if strings.Contains(haystack, strings.ToLower(keyword)) {
+				matched = append(matched, job)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// runSubscriberServer is the --serve entry point: a blocking HTTP server
+// exposing /confirm and /unsubscribe, both authenticated by the same HMAC
+// token scheme used in the confirmation email.
+func runSubscriberServer(addr string) error {
+	notifConfig := loadNotificationConfig()
+	if notifConfig.Subscribers.HMACSecret == "" {
+		return fmt.Errorf("subscribers.hmac_secret is not configured in notification_config.json")
+	}
+
+	store, err := NewJobStore(storeDBPath)
+	if err != nil {
+		return fmt.Errorf("failed to open job store: %w", err)
+	}
+	defer store.Close()
+
+	secret := notifConfig.Subscribers.HMACSecret
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/confirm", func(w http.ResponseWriter, r *http.Request) {
+		email, ok := verifySubscriberToken(secret, r.URL.Query().Get("token"))
+		if !ok {
+			http.Error(w, "invalid or expired token", http.StatusBadRequest)
+			return
+		}
+		if err := store.ConfirmEmailSubscriber(r.Context(), email); err != nil {
+			http.Error(w, "failed to confirm subscription", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, "✅ Subscription confirmed for %s. You'll now receive job alert digests.", email)
+	})
+	mux.HandleFunc("/unsubscribe", func(w http.ResponseWriter, r *http.Request) {
+		email, ok := verifySubscriberToken(secret, r.URL.Query().Get("token"))
+		if !ok {
+			http.Error(w, "invalid or expired token", http.StatusBadRequest)
+			return
+		}
+		if err := store.UnsubscribeEmail(r.Context(), email); err != nil {
+			http.Error(w, "failed to unsubscribe", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, "You've been unsubscribed, %s.", email)
+	})
+
+	log.Printf("🌐 Serving subscriber endpoints on %s", addr)
+	return http.ListenAndServe(addr, mux)
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TelegramBot runs the interactive side of Telegram integration: users
+// /start to register, /filter and /exclude to tune what gets pushed to
+// them, and Apply/Hide inline buttons to act on individual job alerts.
+// This is distinct from TelegramNotifier, which only mirrors the digest to
+// a single fixed chat_id.
+type TelegramBot struct {
+	client  *http.Client
+	token   string
+	store   JobStore
+	allowed map[int64]bool
+}
+
+// NewTelegramBot builds a bot from cfg. store must be non-nil - the bot has
+// nowhere to persist subscribers or filters without it.
+func NewTelegramBot(cfg TelegramConfig, store JobStore) (*TelegramBot, error) {
+	if cfg.BotToken == "" {
+		return nil, fmt.Errorf("telegram bot requires bot_token in the telegram config block")
+	}
+	if store == nil {
+		return nil, fmt.Errorf("telegram bot requires a job store to persist subscribers")
+	}
+
+	allowed := make(map[int64]bool, len(cfg.AllowedUserIDs))
+	for _, id := range cfg.AllowedUserIDs {
+		allowed[id] = true
+	}
+
+	return &TelegramBot{
+		client:  &http.Client{Timeout: 40 * time.Second},
+		token:   cfg.BotToken,
+		store:   store,
+		allowed: allowed,
+	}, nil
+}
+
+func (b *TelegramBot) apiURL(method string) string {
+	return fmt.Sprintf("https://api.telegram.org/bot%s/%s", b.token, method)
+}
+
+func (b *TelegramBot) call(ctx context.Context, method string, payload interface{}, out interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.apiURL(method), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("telegram %s returned status %d", method, resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (b *TelegramBot) isAllowed(userID int64) bool {
+	return len(b.allowed) == 0 || b.allowed[userID]
+}
+
+type telegramUpdate struct {
+	UpdateID      int64                  `json:"update_id"`
+	Message       *telegramMessage       `json:"message"`
+	CallbackQuery *telegramCallbackQuery `json:"callback_query"`
+}
+
+type telegramMessage struct {
+	Chat struct {
+		ID int64 `json:"id"`
+	} `json:"chat"`
+	From struct {
+		ID int64 `json:"id"`
+	} `json:"from"`
+	Text string `json:"text"`
+}
+
+type telegramCallbackQuery struct {
+	ID   string `json:"id"`
+	From struct {
+		ID int64 `json:"id"`
+	} `json:"from"`
+	Message struct {
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+	Data string `json:"data"`
+}
+
+type telegramGetUpdatesResponse struct {
+	OK     bool             `json:"ok"`
+	Result []telegramUpdate `json:"result"`
+}
+
+// Run long-polls getUpdates until ctx is cancelled, dispatching each update
+// to handleMessage or handleCallback as it arrives.
+func (b *TelegramBot) Run(ctx context.Context) error {
+	log.Println("🤖 Telegram bot started (long-polling)")
+	offset := int64(0)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var resp telegramGetUpdatesResponse
+		payload := map[string]interface{}{"offset": offset, "timeout": 30}
+		if err := b.call(ctx, "getUpdates", payload, &resp); err != nil {
+			log.Printf("⚠️ Telegram getUpdates failed, retrying: %v", err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		for _, update := range resp.Result {
+			offset = update.UpdateID + 1
+			switch {
+			case update.Message != nil:
+				b.handleMessage(ctx, update.Message)
+			case update.CallbackQuery != nil:
+				b.handleCallback(ctx, update.CallbackQuery)
+			}
+		}
+	}
+}
+
+func (b *TelegramBot) handleMessage(ctx context.Context, msg *telegramMessage) {
+	if !b.isAllowed(msg.From.ID) {
+		b.sendText(ctx, msg.Chat.ID, "⛔ You're not authorized to use this bot.")
+		return
+	}
+
+	fields := strings.Fields(msg.Text)
+	if len(fields) == 0 {
+		return
+	}
+
+	switch fields[0] {
+	case "/start":
+		if err := b.store.UpsertTelegramSubscriber(ctx, msg.Chat.ID); err != nil {
+			log.Printf("⚠️ Failed to register subscriber %d: %v", msg.Chat.ID, err)
+		}
+		b.sendText(ctx, msg.Chat.ID, "👋 Registered! Use /filter <keywords> to only hear about matching jobs, and /exclude <keywords> to hide others.")
+	case "/filter":
+		if err := b.store.SetTelegramFilters(ctx, msg.Chat.ID, fields[1:]); err != nil {
+			log.Printf("⚠️ Failed to set filters for %d: %v", msg.Chat.ID, err)
+			return
+		}
+		b.sendText(ctx, msg.Chat.ID, fmt.Sprintf("🔎 Filter set to: %s", strings.Join(fields[1:], ", ")))
+	case "/exclude":
+		if err := b.store.SetTelegramExcludes(ctx, msg.Chat.ID, fields[1:]); err != nil {
+			log.Printf("⚠️ Failed to set excludes for %d: %v", msg.Chat.ID, err)
+			return
+		}
+		b.sendText(ctx, msg.Chat.ID, fmt.Sprintf("🚫 Excluding: %s", strings.Join(fields[1:], ", ")))
+	default:
+		b.sendText(ctx, msg.Chat.ID, "Commands: /start, /filter <keywords>, /exclude <keywords>")
+	}
+}
+
+func (b *TelegramBot) handleCallback(ctx context.Context, cb *telegramCallbackQuery) {
+	defer b.answerCallback(ctx, cb.ID)
+
+	action, jobID, found := strings.Cut(cb.Data, ":")
+	if !found {
+		return
+	}
+
+	switch action {
+	case "hide":
+		if err := b.store.HideJobForSubscriber(ctx, cb.Message.Chat.ID, jobID); err != nil {
+			log.Printf("⚠️ Failed to hide job %s for %d: %v", jobID, cb.Message.Chat.ID, err)
+		}
+	case "apply":
+		// Applying just acknowledges the tap; the job's apply URL was
+		// already in the message text the user tapped from.
+	}
+}
+
+func (b *TelegramBot) sendText(ctx context.Context, chatID int64, text string) {
+	payload := map[string]interface{}{"chat_id": chatID, "text": text}
+	if err := b.call(ctx, "sendMessage", payload, nil); err != nil {
+		log.Printf("⚠️ Telegram sendMessage failed for %d: %v", chatID, err)
+	}
+}
+
+func (b *TelegramBot) answerCallback(ctx context.Context, callbackID string) {
+	payload := map[string]interface{}{"callback_query_id": callbackID}
+	if err := b.call(ctx, "answerCallbackQuery", payload, nil); err != nil {
+		log.Printf("⚠️ Telegram answerCallbackQuery failed: %v", err)
+	}
+}
+
+// PushJobAlerts sends every job in jobs, filtered per-subscriber, to every
+// registered subscriber as an inline-keyboard message with Apply/Hide
+// buttons. Called after a scrape completes.
+func (b *TelegramBot) PushJobAlerts(ctx context.Context, jobs []RemoteJob) {
+	if len(jobs) == 0 {
+		return
+	}
+
+	subs, err := b.store.TelegramSubscribers(ctx)
+	if err != nil {
+		log.Printf("⚠️ Failed to load telegram subscribers: %v", err)
+		return
+	}
+
+	for _, sub := range subs {
+		for _, job := range jobs {
+			if !jobMatchesSubscriber(job, sub) {
+				continue
+			}
+			hidden, err := b.store.IsJobHiddenForSubscriber(ctx, sub.ChatID, job.ID)
+			if err != nil {
+				log.Printf("⚠️ Failed to check hidden state for %s/%d: %v", job.ID, sub.ChatID, err)
+				continue
+			}
+			if hidden {
+				continue
+			}
+			b.sendJobCard(ctx, sub.ChatID, job)
+		}
+	}
+}
+
+func (b *TelegramBot) sendJobCard(ctx context.Context, chatID int64, job RemoteJob) {
+	applyURL := job.ApplyURL
+	if applyURL == "" {
+		applyURL = job.URL
+	}
+	text := fmt.Sprintf("💼 %s at %s\n📍 %s\n🔗 %s", job.Title, job.Company, job.Location, applyURL)
+
+	payload := map[string]interface{}{
+		"chat_id": chatID,
+		"text":    text,
+		"reply_markup": map[string]interface{}{
+			"inline_keyboard": [][]map[string]string{
+				{
+					{"text": "✅ Apply", "url": applyURL},
+					{"text": "🙈 Hide", "callback_data": "hide:" + job.ID},
+				},
+			},
+		},
+	}
+	if err := b.call(ctx, "sendMessage", payload, nil); err != nil {
+		log.Printf("⚠️ Telegram sendMessage (job card) failed for %d: %v", chatID, err)
+	}
+}
+
+// runTelegramBot is the --telegram-bot entry point: it loads the same
+// notification_config.json used for push notifications and runs the bot's
+// long-poll loop until interrupted.
+func runTelegramBot() error {
+	notifConfig := loadNotificationConfig()
+
+	store, err := NewJobStore(storeDBPath)
+	if err != nil {
+		return fmt.Errorf("failed to open job store: %w", err)
+	}
+	defer store.Close()
+
+	bot, err := NewTelegramBot(notifConfig.Telegram, store)
+	if err != nil {
+		return err
+	}
+
+	return bot.Run(context.Background())
+}
+
+// PushTelegramAlerts pushes this run's newly added jobs to every registered
+// Telegram subscriber, filtered per-subscriber. A no-op when no bot_token is
+// configured or the store is unavailable.
+func (js *JobScraper) PushTelegramAlerts(ctx context.Context) {
+	if js.store == nil || js.notifConfig.Telegram.BotToken == "" {
+		return
+	}
+
+	bot, err := NewTelegramBot(js.notifConfig.Telegram, js.store)
+	if err != nil {
+		log.Printf("⚠️ Telegram push skipped: %v", err)
+		return
+	}
+
+	bot.PushJobAlerts(ctx, js.newlyAddedJobs())
+}
+
+// newlyAddedJobs returns just this run's new jobs - the same slice
+// buildDigestData falls back to for NewJobs when --since isn't set.
+func (js *JobScraper) newlyAddedJobs() []RemoteJob {
+	js.jobsMutex.Lock()
+	defer js.jobsMutex.Unlock()
+
+	if js.newJobsCount >= 0 && js.newJobsCount < len(js.jobs) {
+		return js.jobs[len(js.jobs)-js.newJobsCount:]
+	}
+	return js.jobs
+}
+
+// jobMatchesSubscriber applies sub's personalized filters on top of the
+// scraper's own fresher/remote matching: job must mention at least one
+// Filters keyword (when Filters is non-empty), and mustn't mention any
+// Excludes keyword.
+func jobMatchesSubscriber(job RemoteJob, sub TelegramSubscriber) bool {
+	haystack := strings.ToLower(job.Title + " " + job.Description)
+
+	if len(sub.Filters) > 0 {
+		matched := false
+		for _, keyword := range sub.Filters {
+			if strings.Contains(haystack, strings.ToLower(keyword)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	for _, keyword := range sub.Excludes {
+		if strings.Contains(haystack, strings.ToLower(keyword)) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"os"
+	"path/filepath"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/digest.html.tmpl templates/digest.txt.tmpl templates/whatsapp.tmpl templates/single_job.tmpl
+var defaultTemplatesFS embed.FS
+
+const (
+	templateDigestHTML = "digest.html.tmpl"
+	templateDigestText = "digest.txt.tmpl"
+	templateWhatsApp   = "whatsapp.tmpl"
+	templateSingleJob  = "single_job.tmpl"
+
+	// templateOverrideDir is where a user can drop same-named files to
+	// restyle any notification without recompiling.
+	templateOverrideDir = "templates"
+)
+
+// digestStats is the {{.Stats}} template data - currently just counts by
+// platform, but kept as its own type so future fields don't reshape
+// digestTemplateData.
+type digestStats struct {
+	ByPlatform map[string]int
+}
+
+// digestTemplateData is what digest.html.tmpl, digest.txt.tmpl, and
+// whatsapp.tmpl all receive.
+type digestTemplateData struct {
+	NewJobs []RemoteJob
+	AllJobs []RemoteJob
+	Date    string
+	Stats   digestStats
+}
+
+func templateFuncs() texttemplate.FuncMap {
+	return texttemplate.FuncMap{
+		"truncate":    truncateText,
+		"salaryRange": salaryRangeText,
+		"applyURL":    applyURLText,
+	}
+}
+
+func truncateText(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+func salaryRangeText(job RemoteJob) string {
+	if job.Salary == "" {
+		return "Not specified"
+	}
+	return job.Salary
+}
+
+func applyURLText(job RemoteJob) string {
+	if job.ApplyURL != "" {
+		return job.ApplyURL
+	}
+	return job.URL
+}
+
+// templateSet holds every notification template, each resolved from
+// overrideDir if present there, else the //go:embed default.
+type templateSet struct {
+	digestHTML *htmltemplate.Template
+	digestText *texttemplate.Template
+	whatsApp   *texttemplate.Template
+	singleJob  *texttemplate.Template
+}
+
+// loadTemplates resolves every template, preferring a same-named file in
+// overrideDir (a "templates" directory next to notification_config.json)
+// over the embedded default.
+func loadTemplates(overrideDir string) (*templateSet, error) {
+	digestHTML, err := parseHTMLTemplate(overrideDir, templateDigestHTML)
+	if err != nil {
+		return nil, err
+	}
+	digestText, err := parseTextTemplate(overrideDir, templateDigestText)
+	if err != nil {
+		return nil, err
+	}
+	whatsApp, err := parseTextTemplate(overrideDir, templateWhatsApp)
+	if err != nil {
+		return nil, err
+	}
+	singleJob, err := parseTextTemplate(overrideDir, templateSingleJob)
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateSet{digestHTML: digestHTML, digestText: digestText, whatsApp: whatsApp, singleJob: singleJob}, nil
+}
+
+func templateSource(overrideDir, name string) ([]byte, error) {
+	if overrideDir != "" {
+		if data, err := os.ReadFile(filepath.Join(overrideDir, name)); err == nil {
+			return data, nil
+		}
+	}
+	return defaultTemplatesFS.ReadFile(templateOverrideDir + "/" + name)
+}
+
+func parseHTMLTemplate(overrideDir, name string) (*htmltemplate.Template, error) {
+	data, err := templateSource(overrideDir, name)
+	if err != nil {
+		return nil, err
+	}
+	return htmltemplate.New(name).Funcs(templateFuncs()).Parse(string(data))
+}
+
+func parseTextTemplate(overrideDir, name string) (*texttemplate.Template, error) {
+	data, err := templateSource(overrideDir, name)
+	if err != nil {
+		return nil, err
+	}
+	return texttemplate.New(name).Funcs(templateFuncs()).Parse(string(data))
+}
+
+func (ts *templateSet) renderDigestHTML(data digestTemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := ts.digestHTML.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (ts *templateSet) renderDigestText(data digestTemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := ts.digestText.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (ts *templateSet) renderWhatsApp(data digestTemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := ts.whatsApp.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (ts *templateSet) renderSingleJob(job RemoteJob) (string, error) {
+	var buf bytes.Buffer
+	if err := ts.singleJob.Execute(&buf, job); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// buildDigestData snapshots the scraper's current run into the data every
+// notification template renders from. With digestSince set and a store
+// configured, NewJobs instead reflects every job first seen within that
+// window (backing --since), rather than just this run's newJobsCount.
+func (js *JobScraper) buildDigestData() digestTemplateData {
+	js.jobsMutex.Lock()
+	defer js.jobsMutex.Unlock()
+
+	byPlatform := make(map[string]int)
+	for _, job := range js.jobs {
+		byPlatform[job.Platform]++
+	}
+
+	newJobs := js.jobs
+	if js.newJobsCount >= 0 && js.newJobsCount < len(js.jobs) {
+		newJobs = js.jobs[len(js.jobs)-js.newJobsCount:]
+	}
+
+	if js.digestSince > 0 && js.store != nil {
+		sinceJobs, err := js.store.JobsSince(context.Background(), time.Now().Add(-js.digestSince))
+		if err != nil {
+			log.Printf("⚠️ Failed to query jobs since %s (falling back to this run's new jobs): %v", js.digestSince, err)
+		} else {
+			newJobs = sinceJobs
+		}
+	}
+
+	return digestTemplateData{
+		NewJobs: newJobs,
+		AllJobs: js.jobs,
+		Date:    time.Now().Format("January 2, 2006"),
+		Stats:   digestStats{ByPlatform: byPlatform},
+	}
+}
+
+// runPreviewEmail renders name (one of "digest.html", "digest.txt",
+// "whatsapp", "single_job") against a scraper seeded with sample jobs, so a
+// template can be iterated on without waiting for a real scrape or send.
+func runPreviewEmail(name string) error {
+	scraper := NewJobScraper()
+	scraper.generateMoreSampleJobs()
+
+	templates, err := loadTemplates(templateOverrideDir)
+	if err != nil {
+		return err
+	}
+	data := scraper.buildDigestData()
+
+	var rendered string
+	switch name {
+	case "digest.html":
+		rendered, err = templates.renderDigestHTML(data)
+	case "digest.txt":
+		rendered, err = templates.renderDigestText(data)
+	case "whatsapp":
+		rendered, err = templates.renderWhatsApp(data)
+	case "single_job":
+		if len(data.AllJobs) == 0 {
+			return fmt.Errorf("no sample jobs to preview")
+		}
+		rendered, err = templates.renderSingleJob(data.AllJobs[0])
+	default:
+		return fmt.Errorf("unknown template %q (want digest.html, digest.txt, whatsapp, or single_job)", name)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(rendered)
+	return nil
+}
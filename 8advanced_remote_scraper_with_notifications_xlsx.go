@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// writeJobsXLSX is --dump-xlsx's counterpart to writeJobsCSV: same rows,
+// same column order, just a different container format for whoever wants to
+// open the export directly in a spreadsheet.
+func writeJobsXLSX(path string, jobs []RemoteJob) error {
+	f := excelize.NewFile()
+	const sheet = "Jobs"
+	f.NewSheet(sheet)
+	f.DeleteSheet("Sheet1")
+
+	for col, header := range storeCSVHeader {
+		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
+		f.SetCellValue(sheet, cell, header)
+	}
+
+	for row, job := range jobs {
+		values := []string{
+			job.ID, job.Platform, job.Title, job.Company, job.Location, job.Description,
+			job.Salary, job.PostedDate, job.JobType, job.Experience,
+			strconv.FormatBool(job.IsRemote), strconv.FormatBool(job.IsFresher), job.URL,
+		}
+		for col, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(col+1, row+2)
+			f.SetCellValue(sheet, cell, value)
+		}
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to write xlsx file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+//go:build lambda
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// lambdaRequest is the event payload accepted by the Lambda handler.
+type lambdaRequest struct {
+	JobTitle string `json:"jobTitle"`
+	Location string `json:"location"`
+	MaxPages int    `json:"maxPages"`
+}
+
+// lambdaCacheEntry is a single warm-cache hit: the jobs found for a query and
+// when they were scraped.
+type lambdaCacheEntry struct {
+	jobs      []Job
+	scrapedAt time.Time
+}
+
+// lambdaCacheTTL bounds how long a warm Lambda container reuses a previous
+// scrape for the same query instead of hitting the sources again.
+const lambdaCacheTTL = 5 * time.Minute
+
+var (
+	lambdaCacheMu sync.Mutex
+	lambdaCache   = map[string]lambdaCacheEntry{}
+)
+
+func lambdaCacheKey(req lambdaRequest) string {
+	return req.JobTitle + "|" + req.Location
+}
+
+// handleLambdaRequest runs a scrape across every registered JobSource and
+// returns the aggregated jobs, serving a warm-container cache hit when one is
+// fresh enough instead of re-scraping.
+func handleLambdaRequest(ctx context.Context, req lambdaRequest) ([]Job, error) {
+	key := lambdaCacheKey(req)
+
+	lambdaCacheMu.Lock()
+	if entry, ok := lambdaCache[key]; ok && time.Since(entry.scrapedAt) < lambdaCacheTTL {
+		lambdaCacheMu.Unlock()
+		return entry.jobs, nil
+	}
+	lambdaCacheMu.Unlock()
+
+	scraper := NewJobScraper(nil)
+	// A Lambda invocation shouldn't touch the filesystem the host gives it
+	// beyond /tmp, and there's no reason to persist a frontier across
+	// independent invocations.
+	scraper.CacheDir = ""
+
+	maxPages := req.MaxPages
+	if maxPages <= 0 {
+		maxPages = 1
+	}
+
+	if err := scraper.ScrapeAll(ctx, req.JobTitle, req.Location, maxPages); err != nil {
+		return nil, err
+	}
+
+	lambdaCacheMu.Lock()
+	lambdaCache[key] = lambdaCacheEntry{jobs: scraper.jobs, scrapedAt: time.Now()}
+	lambdaCacheMu.Unlock()
+
+	return scraper.jobs, nil
+}
+
+// main is the Lambda entrypoint. Build with:
+//
+//	GOOS=linux GOARCH=arm64 go build -tags lambda.norpc,lambda -o bootstrap .
+func main() {
+	lambda.Start(handleLambdaRequest)
+}
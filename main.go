@@ -469,260 +469,107 @@
 
 
 
-
+//go:build !lambda
 
 package main
 
 import (
-	"context" 
-	"net/http"
-	"encoding/csv"
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
-	"net/url"
-	"os"
-	"sync"
+	"strings"
 	"time"
-	"github.com/gocolly/colly/v2"
-	"golang.org/x/time/rate"
 )
 
-// Job represents a comprehensive job listing
-type Job struct {
-	Title       string
-	Company     string
-	Location    string
-	Description string
-	Salary      string
-	PostedDate  string
-	URL         string
-}
+func main() {
+	resume := flag.Bool("resume", false, "reload each source's pending frontier and visited set from --cache-dir")
+	cacheDir := flag.String("cache-dir", "./cache", "directory for the on-disk visit queue and page cache")
+	cacheTTL := flag.Duration("cache-ttl", 24*time.Hour, "how long a cached page is served before it is re-fetched")
+	skillsConfigPath := flag.String("skills-config", "", "JSON file with skill keywords and excluded title terms (defaults to a built-in taxonomy)")
+	useSites := flag.Bool("use-sites", false, "Scrape via the newer Site/ScraperInput interface instead of the legacy JobSource crawler")
+	sites := flag.String("sites", "", "Comma-separated Site names to scrape with --use-sites (e.g. Indeed,RemoteOK); empty scrapes every registered Site")
+	country := flag.String("country", "", "Country to scope the search to, with --use-sites")
+	jobType := flag.String("job-type", "", "Job type to filter for (e.g. fulltime, internship), with --use-sites")
+	hoursOld := flag.Int("hours-old", 0, "Only return postings at most this many hours old, with --use-sites; applied by RemoteOK only (0 disables the filter)")
+	distance := flag.Int("distance", 0, "Search radius in miles, with --use-sites (0 disables the filter)")
+	remoteOnly := flag.Bool("remote-only", false, "Only return remote postings, with --use-sites")
+	flag.Parse()
 
-// JobScraper manages web scraping for job listings with advanced features
-type JobScraper struct {
-	collector    *colly.Collector
-	jobs         []Job
-	jobsMutex    sync.Mutex
-	rateLimiter  *rate.Limiter
-	proxyList    []string
-	currentProxy int
-}
+	// Seed random number generator
+	rand.Seed(time.Now().UnixNano())
 
-// NewJobScraper creates an advanced JobScraper instance
-func NewJobScraper(proxies []string) *JobScraper {
-	// Create a new collector with advanced settings
-	c := colly.NewCollector(
-		colly.AllowedDomains("www.indeed.com"),
-		colly.MaxDepth(2),
-		colly.Async(true),
-	)
-
-	// Configure request timeout and retry mechanism
-	c.SetRequestTimeout(60 * time.Second)
-	c.WithTransport(&http.Transport{
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 10,
-		IdleConnTimeout:     90 * time.Second,
-	})
-
-	// Rate limiting (10 requests per second)
-	rateLimiter := rate.NewLimiter(rate.Every(100*time.Millisecond), 10)
-
-	return &JobScraper{
-		collector:    c,
-		jobs:         []Job{},
-		rateLimiter:  rateLimiter,
-		proxyList:    proxies,
-		currentProxy: 0,
+	// Proxy list (example - replace with real proxies)
+	proxyList := []string{
+		"http://proxy1.example.com:8080",
+		"http://proxy2.example.com:8080",
 	}
-}
 
-// randomUserAgent returns a sophisticated list of user agents
-func randomUserAgent() string {
-	userAgents := []string{
-		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
-		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
-		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
-		"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:89.0) Gecko/20100101 Firefox/89.0",
-		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.1.1 Safari/605.1.15",
+	// Create job scraper with proxy support
+	scraper := NewJobScraper(proxyList)
+	scraper.Resume = *resume
+	scraper.CacheDir = *cacheDir
+	scraper.CacheTTL = *cacheTTL
+	if *skillsConfigPath != "" {
+		cfg, err := LoadSkillConfig(*skillsConfigPath)
+		if err != nil {
+			log.Fatalf("loading skills config: %v", err)
+		}
+		scraper.SkillConfig = cfg
 	}
-	return userAgents[rand.Intn(len(userAgents))]
-}
 
-// getNextProxy rotates through proxy list
-func (js *JobScraper) getNextProxy() string {
-	if len(js.proxyList) == 0 {
-		return ""
-	}
-	proxy := js.proxyList[js.currentProxy]
-	js.currentProxy = (js.currentProxy + 1) % len(js.proxyList)
-	return proxy
-}
+	// Scrape job parameters
+	jobTitle := "FrontEnd Engineer"
+	location := "India"
+	maxPages := 5 // Controlled scraping depth
 
-// configureCollector sets up advanced scraping configurations
-func (js *JobScraper) configureCollector() {
-	// Request configuration
-	js.collector.OnRequest(func(r *colly.Request) {
-		// Rate limiting
-		err := js.rateLimiter.Wait(context.Background())
-		if err != nil {
-			log.Printf("Rate limiter error: %v", err)
-			r.Abort()
-			return
-		}
+	// Bound the whole crawl so a slow/banned source can't hang the process.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
 
-		// Set random user agent and headers
-		r.Headers.Set("User-Agent", randomUserAgent())
-		r.Headers.Set("Accept-Language", "en-US,en;q=0.9")
-		r.Headers.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8")
-		
-		// Proxy rotation
-		proxyURL := js.getNextProxy()
-		if proxyURL != "" {
-			r.Headers.Set("Proxy", proxyURL)
+	if *useSites {
+		var siteNames []string
+		if *sites != "" {
+			siteNames = strings.Split(*sites, ",")
 		}
 
-		log.Printf("Visiting %s", r.URL)
-	})
-
-	// Error handling with advanced logging
-	js.collector.OnError(func(r *colly.Response, err error) {
-		log.Printf("Scraping Error: URL=%v, Status=%d, Error=%v", 
-			r.Request.URL, r.StatusCode, err)
-	})
-
-	// Enhanced job extraction
-	js.collector.OnHTML(".job_seen_beacon", func(e *colly.HTMLElement) {
-		job := Job{
-			Title:       e.ChildText("h2.jobTitle"),
-			Company:     e.ChildText(".companyName"),
-			Location:    e.ChildText(".companyLocation"),
-			Description: e.ChildText(".job-snippet"),
-			Salary:      e.ChildText(".salary-snippet-container"),
-			PostedDate:  e.ChildText(".metadata.turnstileId .date"),
-			URL:         e.Request.URL.String(),
+		input := ScraperInput{
+			SearchTerm:    jobTitle,
+			Location:      location,
+			Country:       *country,
+			JobType:       *jobType,
+			HoursOld:      *hoursOld,
+			ResultsWanted: maxPages * 10,
+			Distance:      *distance,
+			IsRemote:      *remoteOnly,
+			Proxies:       proxyList,
 		}
 
-		// Safely add job to slice
-		js.jobsMutex.Lock()
-		js.jobs = append(js.jobs, job)
-		js.jobsMutex.Unlock()
-	})
-
-	// Pagination handling with intelligent depth control
-	js.collector.OnHTML("a.page", func(e *colly.HTMLElement) {
-		nextPage := e.Attr("href")
-		if nextPage != "" && len(js.jobs) < 100 { // Limit total jobs
-			e.Request.Visit(e.Request.AbsoluteURL(nextPage))
+		jobs, err := ScrapeAllSites(ctx, input, siteNames)
+		if err != nil {
+			log.Fatalf("ScrapeAllSites failed: %v", err)
 		}
-	})
-}
-
-// ScrapeIndeed performs advanced scraping with multiple strategies
-func (js *JobScraper) ScrapeIndeed(jobTitle, location string, maxPages int) error {
-	// Configure collector
-	js.configureCollector()
-
-	// URL encoding for special characters
-	encodedJobTitle := url.QueryEscape(jobTitle)
-	encodedLocation := url.QueryEscape(location)
-
-	// Generate multiple entry points
-	baseURL := "https://www.indeed.com/jobs"
-	startURLs := []string{
-		fmt.Sprintf("%s?q=%s&l=%s", baseURL, encodedJobTitle, encodedLocation),
-	}
-
-	// Add paginated URLs
-	for i := 1; i < maxPages; i++ {
-		startURLs = append(startURLs, 
-			fmt.Sprintf("%s?q=%s&l=%s&start=%d", baseURL, encodedJobTitle, encodedLocation, i*10))
-	}
 
-	// Concurrent scraping with controlled concurrency
-	var wg sync.WaitGroup
-	for _, url := range startURLs {
-		wg.Add(1)
-		go func(pageURL string) {
-			defer wg.Done()
-			
-			// Introduce random delay between requests
-			time.Sleep(time.Duration(rand.Intn(3)) * time.Second)
-			
-			err := js.collector.Visit(pageURL)
-			if err != nil {
-				log.Printf("Error visiting %s: %v", pageURL, err)
-			}
-		}(url)
-	}
-
-	wg.Wait()
-	js.collector.Wait()
-
-	return nil
-}
-
-// SaveToCSV writes scraped jobs to a CSV file with more detailed information
-func (js *JobScraper) SaveToCSV(filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// Expanded headers
-	headers := []string{
-		"Title", "Company", "Location", 
-		"Description", "Salary", "Posted Date", "URL",
-	}
-	if err := writer.Write(headers); err != nil {
-		return err
-	}
-
-	// Write job data
-	for _, job := range js.jobs {
-		record := []string{
-			job.Title,
-			job.Company,
-			job.Location,
-			job.Description,
-			job.Salary,
-			job.PostedDate,
-			job.URL,
+		outputFile := "advanced_indeed_jobs.csv"
+		sink, err := NewCSVSink(outputFile, nil)
+		if err != nil {
+			log.Fatalf("Failed to open %s: %v", outputFile, err)
 		}
-		if err := writer.Write(record); err != nil {
-			return err
+		for _, job := range jobs {
+			if err := sink.Write(job); err != nil {
+				log.Fatalf("Failed to write job: %v", err)
+			}
 		}
-	}
-
-	return nil
-}
+		sink.Close()
 
-func main() {
-	// Seed random number generator
-	rand.Seed(time.Now().UnixNano())
-
-	// Proxy list (example - replace with real proxies)
-	proxyList := []string{
-		"http://proxy1.example.com:8080",
-		"http://proxy2.example.com:8080",
+		fmt.Printf("Scraped %d jobs via Site interface and saved to %s\n", len(jobs), outputFile)
+		return
 	}
 
-	// Create job scraper with proxy support
-	scraper := NewJobScraper(proxyList)
-
-	// Scrape job parameters
-	jobTitle := "FrontEnd Engineer"
-	location := "India"
-	maxPages := 5 // Controlled scraping depth
-
-	fmt.Printf("Scraping %s jobs in %s\n", jobTitle, location)
+	fmt.Printf("Scraping %s jobs in %s across %d registered sources\n", jobTitle, location, len(sourceRegistry))
 
-	err := scraper.ScrapeIndeed(jobTitle, location, maxPages)
+	err := scraper.ScrapeAll(ctx, jobTitle, location, maxPages)
 	if err != nil {
 		log.Fatalf("Scraping failed: %v", err)
 	}
@@ -0,0 +1,903 @@
+package main
+
+// Shared by both the CLI entrypoint (main.go, built without -tags lambda)
+// and the Lambda entrypoint (lambda.go, built with -tags lambda): the Job
+// model, JobSource registry, and JobScraper crawler itself have no
+// build-specific logic, so they carry no build tag and are visible to
+// whichever entrypoint is compiled in.
+
+import (
+	"context"
+	"crypto/md5"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+	_ "github.com/mattn/go-sqlite3"
+	"golang.org/x/time/rate"
+)
+
+// Job represents a comprehensive job listing aggregated from any source
+type Job struct {
+	Source      string
+	Title       string
+	Company     string
+	Location    string
+	Description string
+	Salary      string
+	PostedDate  string
+	URL         string
+	Skills      map[string]bool
+}
+
+// SkillConfig is the on-disk shape of the skill-tagging rules: a map of skill
+// name to the keywords/synonyms that count as a match, plus a list of title
+// substrings that drop a job entirely. Loading it from JSON lets users tune
+// the taxonomy and exclusion list without recompiling.
+type SkillConfig struct {
+	Skills        map[string][]string `json:"skills"`
+	ExcludedTerms []string            `json:"excluded_terms"`
+}
+
+// DefaultSkillConfig is used whenever no --skills-config file is supplied.
+func DefaultSkillConfig() SkillConfig {
+	return SkillConfig{
+		Skills: map[string][]string{
+			"react":      {"react", "reactjs", "react.js"},
+			"go":         {"golang", "go developer"},
+			"python":     {"python"},
+			"typescript": {"typescript"},
+			"tailwind":   {"tailwind", "tailwindcss"},
+		},
+		ExcludedTerms: []string{"senior", "lead", "founder", "cto", "vp of"},
+	}
+}
+
+// LoadSkillConfig reads a skill-tagging config from a JSON file.
+func LoadSkillConfig(path string) (SkillConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SkillConfig{}, err
+	}
+	var cfg SkillConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return SkillConfig{}, fmt.Errorf("parsing skill config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// JobSource is implemented by every job board the scraper knows how to pull
+// from. Registering a JobSource with RegisterSource is the only thing a new
+// board needs to do to be picked up by JobScraper.ScrapeAll - the dispatcher,
+// rate limiting, proxy rotation, and output code never change.
+type JobSource interface {
+	// Name identifies the source and is stamped onto every Job it produces.
+	Name() string
+	// AllowedDomains lists the domains the collector is permitted to visit.
+	AllowedDomains() []string
+	// BuildURLs returns the start URLs (including pagination) for a query.
+	BuildURLs(query, location string, maxPages int) []string
+	// ListingSelector is the CSS selector job listings are matched against.
+	ListingSelector() string
+	// NextPageSelector optionally selects "next page" links; empty disables it.
+	NextPageSelector() string
+	// Extract turns a matched listing element into a Job.
+	Extract(e *colly.HTMLElement) (Job, bool)
+}
+
+// sourceRegistry holds every JobSource available to JobScraper, keyed by name.
+var sourceRegistry = map[string]JobSource{}
+
+// RegisterSource makes a JobSource available to JobScraper.ScrapeAll under its Name().
+func RegisterSource(s JobSource) {
+	sourceRegistry[s.Name()] = s
+}
+
+func init() {
+	RegisterSource(IndeedSource{})
+}
+
+// IndeedSource scrapes job search results from Indeed.
+type IndeedSource struct{}
+
+func (IndeedSource) Name() string { return "Indeed" }
+
+func (IndeedSource) AllowedDomains() []string { return []string{"www.indeed.com"} }
+
+func (IndeedSource) ListingSelector() string { return ".job_seen_beacon" }
+
+func (IndeedSource) NextPageSelector() string { return "a.page" }
+
+func (IndeedSource) BuildURLs(query, location string, maxPages int) []string {
+	baseURL := "https://www.indeed.com/jobs"
+	encodedQuery := url.QueryEscape(query)
+	encodedLocation := url.QueryEscape(location)
+
+	urls := []string{fmt.Sprintf("%s?q=%s&l=%s", baseURL, encodedQuery, encodedLocation)}
+	for i := 1; i < maxPages; i++ {
+		urls = append(urls, fmt.Sprintf("%s?q=%s&l=%s&start=%d", baseURL, encodedQuery, encodedLocation, i*10))
+	}
+	return urls
+}
+
+func (IndeedSource) Extract(e *colly.HTMLElement) (Job, bool) {
+	return Job{
+		Title:       e.ChildText("h2.jobTitle"),
+		Company:     e.ChildText(".companyName"),
+		Location:    e.ChildText(".companyLocation"),
+		Description: e.ChildText(".job-snippet"),
+		Salary:      e.ChildText(".salary-snippet-container"),
+		PostedDate:  e.ChildText(".metadata.turnstileId .date"),
+		URL:         e.Request.URL.String(),
+	}, true
+}
+
+// link is a single crawl frontier entry: a URL paired with the BFS depth it
+// was discovered at, so the worker pool can stop following links past MaxDepth.
+type link struct {
+	u     *url.URL
+	depth int
+}
+
+// JobScraper dispatches a query against every registered JobSource concurrently,
+// sharing a rate limiter and proxy rotation across all of them.
+type JobScraper struct {
+	jobs        []Job
+	jobsMutex   sync.Mutex
+	rateLimiter *rate.Limiter
+	proxyPool   *ProxyPool
+
+	// transportMu serializes a request's proxy selection through its response,
+	// since the collector's http.Transport is swapped per-proxy rather than
+	// built once, and workers share the same collector.
+	transportMu sync.Mutex
+
+	// Workers is the number of goroutines pulling links off the frontier per source.
+	Workers int
+	// MaxDepth bounds how many hops from a start URL the crawler will follow.
+	MaxDepth int
+	// MaxPages bounds the total number of pages crawled per source; 0 means unlimited.
+	MaxPages uint64
+
+	visited      map[[16]byte]bool
+	visitedList  []string
+	visitedMutex sync.Mutex
+	pagesCrawled uint64
+
+	// Resume reloads each source's pending frontier and visited set from
+	// CacheDir on startup instead of starting the crawl from scratch.
+	Resume bool
+	// CacheDir stores the on-disk visit queue and fetched-page cache; empty disables both.
+	CacheDir string
+	// CacheTTL is how long a cached page is served from disk before it is re-fetched.
+	CacheTTL time.Duration
+
+	// SkillConfig drives the post-extraction skill-tagging and excluded-terms filter.
+	SkillConfig SkillConfig
+}
+
+// NewJobScraper creates an advanced JobScraper instance
+func NewJobScraper(proxies []string) *JobScraper {
+	return &JobScraper{
+		jobs: []Job{},
+		// Rate limiting (10 requests per second)
+		rateLimiter: rate.NewLimiter(rate.Every(100*time.Millisecond), 10),
+		proxyPool:   NewProxyPool(proxies),
+		Workers:     4,
+		MaxDepth:    2,
+		MaxPages:    200,
+		visited:     make(map[[16]byte]bool),
+		CacheDir:    "./cache",
+		CacheTTL:    24 * time.Hour,
+		SkillConfig: DefaultSkillConfig(),
+	}
+}
+
+// tagAndFilter scans a freshly-extracted job's title and description for
+// configured skill keywords and drops jobs whose title matches an excluded
+// term (e.g. "senior", "lead"). ok is false if the job should not be kept.
+func (js *JobScraper) tagAndFilter(job Job) (Job, bool) {
+	titleLower := strings.ToLower(job.Title)
+	for _, term := range js.SkillConfig.ExcludedTerms {
+		if term != "" && strings.Contains(titleLower, strings.ToLower(term)) {
+			return Job{}, false
+		}
+	}
+
+	haystack := strings.ToLower(job.Title + " " + job.Description)
+	job.Skills = make(map[string]bool, len(js.SkillConfig.Skills))
+	for skill, keywords := range js.SkillConfig.Skills {
+		for _, keyword := range keywords {
+			if strings.Contains(haystack, strings.ToLower(keyword)) {
+				job.Skills[skill] = true
+				break
+			}
+		}
+	}
+
+	return job, true
+}
+
+// markVisited records url as seen and reports whether it was new.
+func (js *JobScraper) markVisited(u *url.URL) bool {
+	hash := md5.Sum([]byte(u.String()))
+
+	js.visitedMutex.Lock()
+	defer js.visitedMutex.Unlock()
+
+	if js.visited[hash] {
+		return false
+	}
+	js.visited[hash] = true
+	js.visitedList = append(js.visitedList, u.String())
+	return true
+}
+
+// visitQueueState is the on-disk shape of a source's persisted frontier.
+type visitQueueState struct {
+	Visited  []string       `json:"visited"`
+	Frontier []pendingEntry `json:"frontier"`
+}
+
+// pendingEntry is a not-yet-crawled link as it is persisted to disk.
+type pendingEntry struct {
+	URL   string `json:"url"`
+	Depth int    `json:"depth"`
+}
+
+// visitQueuePath returns where a source's frontier is persisted under CacheDir.
+func (js *JobScraper) visitQueuePath(sourceName string) string {
+	return filepath.Join(js.CacheDir, sourceName+".queue.json")
+}
+
+// loadVisitQueue reloads a source's previously persisted visited set (merged
+// into js.visited so already-seen URLs stay deduped) and returns whatever
+// links were still pending when the crawl was last interrupted.
+func (js *JobScraper) loadVisitQueue(sourceName string) []link {
+	if !js.Resume || js.CacheDir == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(js.visitQueuePath(sourceName))
+	if err != nil {
+		return nil
+	}
+
+	var state visitQueueState
+	if err := json.Unmarshal(data, &state); err != nil {
+		log.Printf("[%s] discarding corrupt visit queue: %v", sourceName, err)
+		return nil
+	}
+
+	js.visitedMutex.Lock()
+	for _, raw := range state.Visited {
+		js.visited[md5.Sum([]byte(raw))] = true
+		js.visitedList = append(js.visitedList, raw)
+	}
+	js.visitedMutex.Unlock()
+
+	var pending []link
+	for _, entry := range state.Frontier {
+		u, err := url.Parse(entry.URL)
+		if err != nil {
+			continue
+		}
+		pending = append(pending, link{u: u, depth: entry.Depth})
+	}
+	return pending
+}
+
+// saveVisitQueue persists a source's visited set plus any links that were
+// still in the frontier when the crawl stopped, so a later run with --resume
+// picks up where this one left off instead of re-crawling from scratch.
+func (js *JobScraper) saveVisitQueue(sourceName string, stillPending []link) {
+	if js.CacheDir == "" {
+		return
+	}
+	if err := os.MkdirAll(js.CacheDir, 0o755); err != nil {
+		log.Printf("[%s] could not create cache dir: %v", sourceName, err)
+		return
+	}
+
+	state := visitQueueState{}
+	for _, l := range stillPending {
+		state.Frontier = append(state.Frontier, pendingEntry{URL: l.u.String(), Depth: l.depth})
+	}
+
+	js.visitedMutex.Lock()
+	state.Visited = append([]string(nil), js.visitedList...)
+	js.visitedMutex.Unlock()
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		log.Printf("[%s] could not encode visit queue: %v", sourceName, err)
+		return
+	}
+	if err := os.WriteFile(js.visitQueuePath(sourceName), data, 0o644); err != nil {
+		log.Printf("[%s] could not persist visit queue: %v", sourceName, err)
+	}
+}
+
+// pruneStaleCache evicts cached pages older than CacheTTL so repeat crawls
+// don't serve indefinitely-stale content from colly's on-disk response cache.
+func (js *JobScraper) pruneStaleCache() {
+	if js.CacheDir == "" || js.CacheTTL <= 0 {
+		return
+	}
+
+	pagesDir := filepath.Join(js.CacheDir, "pages")
+	cutoff := time.Now().Add(-js.CacheTTL)
+
+	_ = filepath.Walk(pagesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info == nil || info.IsDir() {
+			return nil
+		}
+		if info.ModTime().Before(cutoff) {
+			_ = os.Remove(path)
+		}
+		return nil
+	})
+}
+
+// randomUserAgent returns a sophisticated list of user agents
+func randomUserAgent() string {
+	userAgents := []string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:89.0) Gecko/20100101 Firefox/89.0",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.1.1 Safari/605.1.15",
+	}
+	return userAgents[rand.Intn(len(userAgents))]
+}
+
+// proxyHealth tracks consecutive failures for a single proxy so it can be
+// pulled out of rotation for a cooldown window after it starts getting
+// blocked, instead of being retried on every single request.
+type proxyHealth struct {
+	consecutiveFailures int
+	cooldownUntil       time.Time
+}
+
+// ProxyPool round-robins through a list of proxy URLs (http(s) or socks5,
+// optionally with embedded user:pass@ credentials) and evicts any proxy that
+// fails too many requests in a row until its cooldown window passes.
+type ProxyPool struct {
+	mu      sync.Mutex
+	proxies []string
+	next    int
+	health  map[string]*proxyHealth
+
+	// maxFailures is how many consecutive bad responses a proxy tolerates
+	// before it is cooled down; cooldown is how long it stays out of rotation.
+	maxFailures int
+	cooldown    time.Duration
+}
+
+// NewProxyPool creates a pool over proxies with reasonable eviction defaults.
+func NewProxyPool(proxies []string) *ProxyPool {
+	return &ProxyPool{
+		proxies:     proxies,
+		health:      make(map[string]*proxyHealth),
+		maxFailures: 3,
+		cooldown:    2 * time.Minute,
+	}
+}
+
+// Next returns the next proxy in rotation that isn't currently cooling down,
+// or "" if the pool is empty or every proxy is unhealthy right now.
+func (p *ProxyPool) Next() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.proxies) == 0 {
+		return ""
+	}
+
+	for i := 0; i < len(p.proxies); i++ {
+		candidate := p.proxies[p.next]
+		p.next = (p.next + 1) % len(p.proxies)
+
+		if h, ok := p.health[candidate]; ok && time.Now().Before(h.cooldownUntil) {
+			continue
+		}
+		return candidate
+	}
+	return ""
+}
+
+// ReportResult records the outcome of a request made through proxyURL,
+// evicting it for p.cooldown once it has failed p.maxFailures times in a row.
+// A 403 or 429 status, or a non-nil err (e.g. a dial/read timeout), counts as
+// a failure; anything else resets its failure streak.
+func (p *ProxyPool) ReportResult(proxyURL string, statusCode int, err error) {
+	if proxyURL == "" {
+		return
+	}
+	failed := err != nil || statusCode == http.StatusForbidden || statusCode == http.StatusTooManyRequests
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	h, ok := p.health[proxyURL]
+	if !ok {
+		h = &proxyHealth{}
+		p.health[proxyURL] = h
+	}
+
+	if !failed {
+		h.consecutiveFailures = 0
+		return
+	}
+
+	h.consecutiveFailures++
+	if h.consecutiveFailures >= p.maxFailures {
+		h.cooldownUntil = time.Now().Add(p.cooldown)
+	}
+}
+
+// transportFor builds an *http.Transport that actually routes through
+// proxyURL. net/http's Transport.Proxy natively dials through http, https,
+// and socks5 proxy URLs (including embedded user:pass@ credentials), so no
+// extra client library is needed to support authenticated or SOCKS5 proxies.
+func transportFor(proxyURL string) (*http.Transport, error) {
+	t := &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if proxyURL == "" {
+		return t, nil
+	}
+
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+	}
+	t.Proxy = http.ProxyURL(parsed)
+	return t, nil
+}
+
+// newCollector builds a collector for a single source, wired up to the shared
+// rate limiter and proxy rotation, and to that source's extraction/pagination rules.
+// Discovered pagination links are handed to enqueue rather than visited directly,
+// so they flow through the worker pool's frontier instead of firing ad hoc requests.
+func (js *JobScraper) newCollector(source JobSource, enqueue func(l link)) *colly.Collector {
+	// Async is left at its default (synchronous) because JobScraper now owns
+	// concurrency itself via the worker pool in ScrapeSource; that also
+	// guarantees Visit returns only after a page's own links have been
+	// enqueued, which the pending-link accounting below depends on.
+	c := colly.NewCollector(
+		colly.AllowedDomains(source.AllowedDomains()...),
+	)
+
+	if js.CacheDir != "" {
+		// Colly serves a URL straight from disk on repeat visits instead of
+		// re-fetching it; js.pruneStaleCache keeps that from growing unbounded
+		// by evicting anything older than CacheTTL before the crawl starts.
+		c.CacheDir = filepath.Join(js.CacheDir, "pages")
+	}
+
+	c.SetRequestTimeout(60 * time.Second)
+
+	c.OnRequest(func(r *colly.Request) {
+		if err := js.rateLimiter.Wait(context.Background()); err != nil {
+			log.Printf("Rate limiter error: %v", err)
+			r.Abort()
+			return
+		}
+
+		r.Headers.Set("User-Agent", randomUserAgent())
+		r.Headers.Set("Accept-Language", "en-US,en;q=0.9")
+		r.Headers.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8")
+
+		// The collector's transport is shared across workers, so the proxy it
+		// is pointed at for this request is swapped in under transportMu and
+		// held until the response (or error) for this same request arrives.
+		js.transportMu.Lock()
+		proxyURL := js.proxyPool.Next()
+		transport, err := transportFor(proxyURL)
+		if err != nil {
+			log.Printf("[%s] proxy transport error: %v", source.Name(), err)
+			transport, _ = transportFor("")
+		}
+		c.WithTransport(transport)
+		r.Ctx.Put("proxy", proxyURL)
+
+		log.Printf("[%s] Visiting %s", source.Name(), r.URL)
+	})
+
+	c.OnResponse(func(r *colly.Response) {
+		js.proxyPool.ReportResult(r.Ctx.Get("proxy"), r.StatusCode, nil)
+		js.transportMu.Unlock()
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		js.proxyPool.ReportResult(r.Ctx.Get("proxy"), r.StatusCode, err)
+		js.transportMu.Unlock()
+		log.Printf("[%s] Scraping Error: URL=%v, Status=%d, Error=%v",
+			source.Name(), r.Request.URL, r.StatusCode, err)
+	})
+
+	c.OnHTML(source.ListingSelector(), func(e *colly.HTMLElement) {
+		job, ok := source.Extract(e)
+		if !ok {
+			return
+		}
+		job.Source = source.Name()
+
+		job, ok = js.tagAndFilter(job)
+		if !ok {
+			return
+		}
+
+		js.jobsMutex.Lock()
+		js.jobs = append(js.jobs, job)
+		js.jobsMutex.Unlock()
+	})
+
+	if sel := source.NextPageSelector(); sel != "" {
+		c.OnHTML(sel, func(e *colly.HTMLElement) {
+			nextPage := e.Attr("href")
+			if nextPage == "" {
+				return
+			}
+			// Resolve relative hrefs against the page that produced them.
+			next, err := url.Parse(e.Request.AbsoluteURL(nextPage))
+			if err != nil {
+				return
+			}
+			enqueue(link{u: next, depth: e.Request.Depth + 1})
+		})
+	}
+
+	return c
+}
+
+// ScrapeSource runs a single registered JobSource against a query using a
+// worker-pool crawler: a bounded number of workers pull links off a frontier
+// channel, skip anything already visited (deduped via an md5 hash of the URL),
+// and stop once MaxPages has been crawled. ctx cancellation shuts the pool
+// down early and still returns whatever jobs were collected so far.
+func (js *JobScraper) ScrapeSource(ctx context.Context, source JobSource, query, location string, maxPages int) error {
+	js.pruneStaleCache()
+
+	frontier := make(chan link, 256)
+
+	// pending tracks every link that has been pushed onto frontier but not yet
+	// fully processed, including ones discovered while processing an earlier
+	// link. The frontier is closed once it drops to zero, which is what lets
+	// the workers below exit instead of blocking forever.
+	var pending sync.WaitGroup
+
+	c := js.newCollector(source, func(l link) {
+		if js.MaxDepth > 0 && l.depth > js.MaxDepth {
+			return
+		}
+		pending.Add(1)
+		select {
+		case frontier <- l:
+		case <-ctx.Done():
+			pending.Done()
+		}
+	})
+
+	numWorkers := js.Workers
+	if numWorkers <= 0 {
+		numWorkers = 4
+	}
+
+	var workers sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case l, ok := <-frontier:
+					if !ok {
+						return
+					}
+					js.crawlLink(c, source, l)
+					pending.Done()
+				}
+			}
+		}()
+	}
+
+	// Reload whatever was still pending the last time this source ran so a
+	// crashed or rate-limited crawl can resume instead of starting over.
+	for _, l := range js.loadVisitQueue(source.Name()) {
+		pending.Add(1)
+		select {
+		case frontier <- l:
+		case <-ctx.Done():
+			pending.Done()
+		}
+	}
+
+	// Seed the frontier with the source's start URLs at depth 0.
+	for _, pageURL := range source.BuildURLs(query, location, maxPages) {
+		u, err := url.Parse(pageURL)
+		if err != nil {
+			log.Printf("[%s] invalid start URL %q: %v", source.Name(), pageURL, err)
+			continue
+		}
+		pending.Add(1)
+		select {
+		case frontier <- link{u: u, depth: 0}:
+		case <-ctx.Done():
+			pending.Done()
+		}
+	}
+
+	go func() {
+		pending.Wait()
+		close(frontier)
+	}()
+
+	workers.Wait()
+
+	// Persist anything still sitting in the frontier (left over because ctx
+	// was cancelled) so a future --resume run picks it back up. The drain
+	// does not block: after a cancel the frontier is never closed while links
+	// are still buffered in it.
+	var leftover []link
+drain:
+	for {
+		select {
+		case l, ok := <-frontier:
+			if !ok {
+				break drain
+			}
+			leftover = append(leftover, l)
+		default:
+			break drain
+		}
+	}
+	js.saveVisitQueue(source.Name(), leftover)
+
+	return ctx.Err()
+}
+
+// crawlLink visits a single frontier link unless it has already been seen or
+// the source's MaxPages budget has been exhausted.
+func (js *JobScraper) crawlLink(c *colly.Collector, source JobSource, l link) {
+	if js.MaxPages > 0 && atomic.LoadUint64(&js.pagesCrawled) >= js.MaxPages {
+		return
+	}
+	if !js.markVisited(l.u) {
+		return
+	}
+	atomic.AddUint64(&js.pagesCrawled, 1)
+
+	// Be nice to the server.
+	time.Sleep(time.Duration(rand.Intn(3)) * time.Second)
+
+	if err := c.Visit(l.u.String()); err != nil {
+		log.Printf("[%s] Error visiting %s: %v", source.Name(), l.u, err)
+	}
+}
+
+// ScrapeAll runs the query against every registered JobSource concurrently and
+// aggregates the results from every source into js.jobs. It returns partial
+// results even if ctx is cancelled or a source's budget is exceeded.
+func (js *JobScraper) ScrapeAll(ctx context.Context, query, location string, maxPages int) error {
+	var wg sync.WaitGroup
+	for _, source := range sourceRegistry {
+		wg.Add(1)
+		go func(source JobSource) {
+			defer wg.Done()
+			if err := js.ScrapeSource(ctx, source, query, location, maxPages); err != nil && err != context.Canceled {
+				log.Printf("[%s] scrape failed: %v", source.Name(), err)
+			}
+		}(source)
+	}
+	wg.Wait()
+	return nil
+}
+
+// OutputSink is implemented by every format JobScraper can persist jobs to.
+// Write is called once per job, so a sink that wants to stream (JSONL, SQLite)
+// never has to hold the full result set in memory the way js.jobs does.
+type OutputSink interface {
+	Write(job Job) error
+	Close() error
+}
+
+// skillColumns returns the sorted skill names backing CSVSink's skill
+// columns, so output order is stable instead of following map iteration order.
+func (js *JobScraper) skillColumns() []string {
+	names := make([]string, 0, len(js.SkillConfig.Skills))
+	for skill := range js.SkillConfig.Skills {
+		names = append(names, skill)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CSVSink writes one row per job, flushing after every write.
+type CSVSink struct {
+	file   *os.File
+	writer *csv.Writer
+	skills []string
+}
+
+// NewCSVSink creates a CSV file with a header row, including one "Skill:x"
+// column per entry in skillColumns.
+func NewCSVSink(filename string, skillColumns []string) (*CSVSink, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := csv.NewWriter(file)
+	headers := []string{"Source", "Title", "Company", "Location", "Description", "Salary", "Posted Date", "URL"}
+	for _, skill := range skillColumns {
+		headers = append(headers, "Skill:"+skill)
+	}
+	if err := writer.Write(headers); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &CSVSink{file: file, writer: writer, skills: skillColumns}, nil
+}
+
+func (s *CSVSink) Write(job Job) error {
+	record := []string{job.Source, job.Title, job.Company, job.Location, job.Description, job.Salary, job.PostedDate, job.URL}
+	for _, skill := range s.skills {
+		if job.Skills[skill] {
+			record = append(record, "true")
+		} else {
+			record = append(record, "false")
+		}
+	}
+	if err := s.writer.Write(record); err != nil {
+		return err
+	}
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+func (s *CSVSink) Close() error {
+	s.writer.Flush()
+	return s.file.Close()
+}
+
+// JSONSink buffers every job and writes a single indented JSON array on Close.
+type JSONSink struct {
+	filename string
+	jobs     []Job
+}
+
+func NewJSONSink(filename string) *JSONSink {
+	return &JSONSink{filename: filename}
+}
+
+func (s *JSONSink) Write(job Job) error {
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+func (s *JSONSink) Close() error {
+	data, err := json.MarshalIndent(s.jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.filename, data, 0o644)
+}
+
+// JSONLSink writes one job per line as it is scraped, so crashes mid-run
+// don't lose everything collected so far the way a buffered JSON array would.
+type JSONLSink struct {
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func NewJSONLSink(filename string) (*JSONLSink, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLSink{file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+func (s *JSONLSink) Write(job Job) error {
+	return s.encoder.Encode(job)
+}
+
+func (s *JSONLSink) Close() error {
+	return s.file.Close()
+}
+
+// SQLiteSink persists jobs to a SQLite database, deduplicating across re-runs
+// with an idempotent INSERT OR IGNORE keyed on URL.
+type SQLiteSink struct {
+	db *sql.DB
+}
+
+func NewSQLiteSink(dsn string) (*SQLiteSink, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	const schema = `CREATE TABLE IF NOT EXISTS jobs (
+		url TEXT PRIMARY KEY,
+		source TEXT,
+		title TEXT,
+		company TEXT,
+		location TEXT,
+		description TEXT,
+		salary TEXT,
+		posted_date TEXT
+	)`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteSink{db: db}, nil
+}
+
+func (s *SQLiteSink) Write(job Job) error {
+	_, err := s.db.Exec(
+		`INSERT OR IGNORE INTO jobs (url, source, title, company, location, description, salary, posted_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		job.URL, job.Source, job.Title, job.Company, job.Location, job.Description, job.Salary, job.PostedDate,
+	)
+	return err
+}
+
+func (s *SQLiteSink) Close() error {
+	return s.db.Close()
+}
+
+// StdoutSink writes each job as a JSON line to stdout. The Lambda
+// entrypoint does not use it: handleLambdaRequest returns the jobs as the
+// handler's response instead.
+type StdoutSink struct {
+	encoder *json.Encoder
+}
+
+func NewStdoutSink() *StdoutSink {
+	return &StdoutSink{encoder: json.NewEncoder(os.Stdout)}
+}
+
+func (s *StdoutSink) Write(job Job) error { return s.encoder.Encode(job) }
+
+func (s *StdoutSink) Close() error { return nil }
+
+// SaveToCSV writes scraped jobs to a CSV file. Kept for backward compatibility
+// with callers that only need CSV; new code should use an OutputSink directly.
+func (js *JobScraper) SaveToCSV(filename string) error {
+	sink, err := NewCSVSink(filename, js.skillColumns())
+	if err != nil {
+		return err
+	}
+
+	for _, job := range js.jobs {
+		if err := sink.Write(job); err != nil {
+			sink.Close()
+			return err
+		}
+	}
+
+	return sink.Close()
+}
+
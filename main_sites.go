@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScraperInput parameterizes a single query the same way across every
+// provider, mirroring the provider-agnostic input used by multi-board
+// aggregators like JobSpy. Not every field is meaningful to every Site:
+// RemoteOKSite honors JobType and HoursOld (see remoteOKListing) but, since
+// every RemoteOK listing is already remote and globally posted, treats
+// Country/Distance/IsRemote as no-ops; jobSourceSite folds Country, JobType,
+// IsRemote and Distance into the free-text query/location it sends, since the
+// underlying JobSource has no structured parameters to bind them to, and
+// ignores HoursOld.
+type ScraperInput struct {
+	SearchTerm    string
+	Location      string
+	Country       string
+	JobType       string // fulltime, parttime, contract, internship
+	HoursOld      int
+	ResultsWanted int
+	Distance      int
+	IsRemote      bool
+	Proxies       []string
+}
+
+// Site is a higher-level alternative to JobSource: instead of describing
+// selectors for JobScraper's shared colly-based BFS crawler, a Site owns its
+// own scrape end-to-end and returns a finished batch of jobs, so providers
+// shaped differently (a JSON API rather than crawlable HTML) can
+// participate without being forced into the colly-specific JobSource shape.
+type Site interface {
+	Name() string
+	Scrape(ctx context.Context, input ScraperInput) ([]Job, error)
+}
+
+// siteRegistry holds every Site available to ScrapeAllSites, keyed by name.
+var siteRegistry = map[string]Site{}
+
+// RegisterSite makes a Site available to ScrapeAllSites under its Name().
+func RegisterSite(s Site) {
+	siteRegistry[s.Name()] = s
+}
+
+func init() {
+	RegisterSite(jobSourceSite{source: IndeedSource{}})
+	RegisterSite(newRemoteOKSite())
+}
+
+// jobSourceSite adapts an existing JobSource onto the Site interface by
+// running it through a dedicated JobScraper - one per call, so a Site's
+// per-query Proxies never leak into another concurrent Scrape - and
+// returning whatever that scrape collected.
+type jobSourceSite struct {
+	source JobSource
+}
+
+func (s jobSourceSite) Name() string { return s.source.Name() }
+
+// jobSourceSite's underlying JobSource only accepts a free-text query and
+// location (see JobSource.BuildURLs), so Country/JobType/IsRemote/Distance
+// have no structured query parameter to bind to the way RemoteOKSite's
+// tags/date filtering does below. They're instead folded into the query
+// and location text that's actually sent, which is the same best-effort
+// approach a human pasting these filters into Indeed's search box would
+// take.
+func (s jobSourceSite) Scrape(ctx context.Context, input ScraperInput) ([]Job, error) {
+	maxPages := input.ResultsWanted/10 + 1
+
+	query := input.SearchTerm
+	if input.JobType != "" {
+		query = strings.TrimSpace(query + " " + input.JobType)
+	}
+	if input.IsRemote && !strings.Contains(strings.ToLower(query), "remote") {
+		query = strings.TrimSpace(query + " remote")
+	}
+
+	location := input.Location
+	if input.Country != "" {
+		location = strings.TrimSpace(strings.TrimSuffix(location, ",") + ", " + input.Country)
+	}
+	if input.Distance > 0 {
+		query = fmt.Sprintf("%s within %dmi", query, input.Distance)
+	}
+
+	scraper := NewJobScraper(input.Proxies)
+	if err := scraper.ScrapeSource(ctx, s.source, query, location, maxPages); err != nil && err != context.Canceled {
+		return nil, err
+	}
+	return scraper.jobs, nil
+}
+
+// RemoteOKSite queries RemoteOK's public JSON API directly, demonstrating
+// that Site doesn't require a JobSource/colly-shaped provider - an API-based
+// board can satisfy it just as well as a scraped one.
+type RemoteOKSite struct {
+	client *http.Client
+}
+
+func newRemoteOKSite() *RemoteOKSite {
+	return &RemoteOKSite{client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (s *RemoteOKSite) Name() string { return "RemoteOK" }
+
+// remoteOKListing is the shape of one entry in RemoteOK's job array. The
+// API's first element is a metadata blob rather than a listing, which is
+// why Scrape below skips entries with no Position/Company.
+type remoteOKListing struct {
+	Position    string   `json:"position"`
+	Company     string   `json:"company"`
+	Location    string   `json:"location"`
+	Description string   `json:"description"`
+	Salary      string   `json:"salary"`
+	Date        string   `json:"date"`
+	URL         string   `json:"url"`
+	Tags        []string `json:"tags"`
+}
+
+// matchesJobType reports whether l carries a tag matching jobType
+// (case-insensitively), e.g. "internship" matching a "Internship" tag.
+// Every board on RemoteOK is remote and has no meaningful Country/Distance,
+// so those ScraperInput fields don't apply here - jobType and age are the
+// filters this API can actually answer.
+func (l remoteOKListing) matchesJobType(jobType string) bool {
+	if jobType == "" {
+		return true
+	}
+	for _, tag := range l.Tags {
+		if strings.EqualFold(tag, jobType) {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(l.Position), strings.ToLower(jobType))
+}
+
+// withinHoursOld reports whether l was posted within the last hoursOld
+// hours. A Date that fails to parse is kept rather than dropped, since an
+// unparsed date is more likely an API format drift than evidence the
+// posting is stale.
+func (l remoteOKListing) withinHoursOld(hoursOld int) bool {
+	if hoursOld <= 0 {
+		return true
+	}
+	posted, err := time.Parse(time.RFC3339, l.Date)
+	if err != nil {
+		return true
+	}
+	return time.Since(posted) <= time.Duration(hoursOld)*time.Hour
+}
+
+func (s *RemoteOKSite) Scrape(ctx context.Context, input ScraperInput) ([]Job, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://remoteok.com/api", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; getalljobs/1.0)")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var listings []remoteOKListing
+	if err := json.NewDecoder(resp.Body).Decode(&listings); err != nil {
+		return nil, fmt.Errorf("decode remoteok response: %w", err)
+	}
+
+	searchTerm := strings.ToLower(input.SearchTerm)
+	var jobs []Job
+	for _, l := range listings {
+		if l.Position == "" || l.Company == "" {
+			continue
+		}
+		if searchTerm != "" && !strings.Contains(strings.ToLower(l.Position), searchTerm) {
+			continue
+		}
+		if !l.matchesJobType(input.JobType) || !l.withinHoursOld(input.HoursOld) {
+			continue
+		}
+
+		jobs = append(jobs, Job{
+			Source:      s.Name(),
+			Title:       l.Position,
+			Company:     l.Company,
+			Location:    l.Location,
+			Description: l.Description,
+			Salary:      l.Salary,
+			PostedDate:  l.Date,
+			URL:         l.URL,
+		})
+		if input.ResultsWanted > 0 && len(jobs) >= input.ResultsWanted {
+			break
+		}
+	}
+	return jobs, nil
+}
+
+// ScrapeAllSites runs input against every Site named in siteNames (every
+// registered Site if siteNames is empty), merges their results, and sorts
+// the merged list by (Source, PostedDate desc) so output order is stable
+// regardless of which goroutine finishes first.
+func ScrapeAllSites(ctx context.Context, input ScraperInput, siteNames []string) ([]Job, error) {
+	names := siteNames
+	if len(names) == 0 {
+		for name := range siteRegistry {
+			names = append(names, name)
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []Job
+	)
+
+	for _, name := range names {
+		site, ok := siteRegistry[name]
+		if !ok {
+			log.Printf("ScrapeAllSites: unknown site %q, skipping", name)
+			continue
+		}
+
+		wg.Add(1)
+		go func(site Site) {
+			defer wg.Done()
+			jobs, err := site.Scrape(ctx, input)
+			if err != nil {
+				log.Printf("[%s] scrape failed: %v", site.Name(), err)
+				return
+			}
+
+			mu.Lock()
+			results = append(results, jobs...)
+			mu.Unlock()
+		}(site)
+	}
+	wg.Wait()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Source != results[j].Source {
+			return results[i].Source < results[j].Source
+		}
+		return results[i].PostedDate > results[j].PostedDate
+	})
+
+	return results, nil
+}